@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"reflect"
+	"time"
+)
+
+// adaptiveTTL holds the bounds set with SetAdaptiveTTL.
+type adaptiveTTL[V any] struct {
+	minTTL time.Duration
+	maxTTL time.Duration
+	equal  func(a, b V) bool
+}
+
+// SetAdaptiveTTL enables adjusting the time to live of each value based on how often it actually changes.
+// Each time a value is reloaded, it's compared to the previous value with equal.
+// If it hasn't changed, then its time to live is doubled, up to maxTTL, and if it has changed, then its time to live is halved, down to minTTL.
+// This reduces loads of stable data, while keeping data that changes often fresh.
+//
+// Each value starts with the TTL policy set with SetTTLPolicy, limited to the given bounds, or minTTL if there is no TTL policy.
+// Values with a time to live set with SetKeyTTL, pinned values, and values in a MultiCache created with NewMultiWithTTL are not adjusted.
+// If equal is nil, then values are compared with reflect.DeepEqual.
+// This method will panic if minTTL <= 0, or maxTTL < minTTL.
+func (m *MultiCache[K, V]) SetAdaptiveTTL(minTTL, maxTTL time.Duration, equal func(a, b V) bool) {
+	if minTTL <= 0 {
+		panic("min ttl <= 0")
+	}
+	if maxTTL < minTTL {
+		panic("max ttl < min ttl")
+	}
+	if equal == nil {
+		equal = func(a, b V) bool {
+			return reflect.DeepEqual(a, b)
+		}
+	}
+	m.adaptive.Store(&adaptiveTTL[V]{minTTL: minTTL, maxTTL: maxTTL, equal: equal})
+}
+
+// next returns the time to live for a newly loaded value, given the value it replaced, if any.
+// cur is the time to live chosen for the previous value, or 0 if this is the first value, in which case base is limited to the bounds instead.
+func (a *adaptiveTTL[V]) next(cur, base time.Duration, prev *V, val V) time.Duration {
+	switch {
+	case cur <= 0:
+		return a.clamp(base)
+	case prev == nil:
+		return cur
+	case a.equal(*prev, val):
+		return a.clamp(cur * 2)
+	default:
+		return a.clamp(cur / 2)
+	}
+}
+
+// clamp limits ttl to the bounds of the adaptiveTTL.
+func (a *adaptiveTTL[V]) clamp(ttl time.Duration) time.Duration {
+	if ttl < a.minTTL {
+		return a.minTTL
+	}
+	if ttl > a.maxTTL {
+		return a.maxTTL
+	}
+	return ttl
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetAdaptiveTTL(t *testing.T) {
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		return 1, nil
+	})
+	mc.SetTTLPolicy(time.Minute)
+	mc.SetAdaptiveTTL(30*time.Second, 4*time.Minute, nil)
+
+	assertTTL := func(expected time.Duration) {
+		t.Helper()
+		remaining, ok := mc.TTLRemaining("a")
+		require.True(t, ok)
+		assert.InDelta(t, expected, remaining, float64(time.Second))
+	}
+	mc.MustGet("a")
+	assertTTL(time.Minute)
+
+	// Unchanged values have their TTL doubled, up to the maximum.
+	mc.Set("a", 1)
+	assertTTL(2 * time.Minute)
+	mc.Set("a", 1)
+	assertTTL(4 * time.Minute)
+	mc.Set("a", 1)
+	assertTTL(4 * time.Minute)
+
+	// Changed values have their TTL halved, down to the minimum.
+	for _, val := range []int{2, 3, 4, 5} {
+		mc.Set("a", val)
+	}
+	assertTTL(30 * time.Second)
+
+	mc.SetKeyTTL("b", 10*time.Minute)
+	mc.MustGet("b")
+	mc.Set("b", 1)
+	remaining, ok := mc.TTLRemaining("b")
+	require.True(t, ok)
+	assert.InDelta(t, 10*time.Minute, remaining, float64(time.Second))
+
+	assert.Panics(t, func() {
+		mc.SetAdaptiveTTL(0, time.Minute, nil)
+	})
+	assert.Panics(t, func() {
+		mc.SetAdaptiveTTL(time.Minute, time.Second, nil)
+	})
+}
+
+func TestMultiCache_SetAdaptiveTTL_Reload(t *testing.T) {
+	var version atomic.Int32
+	mc := NewMulti[string, int32](func(key string) (int32, error) {
+		return version.Load(), nil
+	})
+	mc.SetAdaptiveTTL(20*time.Millisecond, time.Hour, func(a, b int32) bool {
+		return a == b
+	})
+
+	mc.MustGet("a")
+	time.Sleep(30 * time.Millisecond)
+	mc.MustGet("a")
+	remaining, ok := mc.TTLRemaining("a")
+	require.True(t, ok)
+	assert.Greater(t, remaining, 20*time.Millisecond)
+}
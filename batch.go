@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// microBatcher coalesces loads of individual keys requested within a window into a single call to a batch loader.
+type microBatcher[K comparable, V any] struct {
+	window  time.Duration
+	loader  MultiBatchLoaderFunc[K, V]
+	mux     sync.Mutex
+	pending *microBatch[K, V]
+}
+
+// microBatch is a set of keys that will be loaded together.
+// Once done is closed, vals and err hold the result of the batch loader.
+type microBatch[K comparable, V any] struct {
+	keys []K
+	seen map[K]struct{}
+	done chan struct{}
+	vals map[K]V
+	err  error
+}
+
+// load adds key to the pending batch, starting a new batch if needed, and waits for the batch to be loaded.
+func (b *microBatcher[K, V]) load(key K) (V, error) {
+	b.mux.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &microBatch[K, V]{
+			seen: map[K]struct{}{},
+			done: make(chan struct{}),
+		}
+		b.pending = batch
+		time.AfterFunc(b.window, func() {
+			b.flush(batch)
+		})
+	}
+	if _, ok := batch.seen[key]; !ok {
+		batch.seen[key] = struct{}{}
+		batch.keys = append(batch.keys, key)
+	}
+	b.mux.Unlock()
+
+	<-batch.done
+	if batch.err != nil {
+		var mt V
+		return mt, batch.err
+	}
+	val, ok := batch.vals[key]
+	if !ok {
+		return val, missingFromBatch(key)
+	}
+	return val, nil
+}
+
+// flush loads every key in batch, and releases the callers waiting on it.
+func (b *microBatcher[K, V]) flush(batch *microBatch[K, V]) {
+	b.mux.Lock()
+	if b.pending == batch {
+		b.pending = nil
+	}
+	b.mux.Unlock()
+	batch.vals, batch.err = b.loader(batch.keys)
+	close(batch.done)
+}
+
+// SetBatchWindow enables coalescing of misses for a MultiCache created with NewMultiBatch.
+// When a key is missed, its load will wait for up to window, and every other key missed in that time will be loaded with the same call to the batch loader.
+// This turns a burst of individual lookups into a single round trip to the underlying data source, at the cost of adding up to window to the latency of each miss.
+// A small window, such as a few milliseconds, is usually enough.
+//
+// GetMulti and Preheat already load their keys in a single batch, so this mostly benefits concurrent calls to Get.
+// This method will panic if window <= 0, or the MultiCache wasn't created with NewMultiBatch.
+func (m *MultiCache[K, V]) SetBatchWindow(window time.Duration) {
+	if window <= 0 {
+		panic("window <= 0")
+	}
+	if m.batchLoader == nil {
+		panic("not a batch cache")
+	}
+	m.batcher.Store(&microBatcher[K, V]{
+		window: window,
+		loader: m.batchLoader,
+	})
+}
+
+// loadKey loads the value for key with the MultiCache's loader, coalescing it with other loads if a batch window is set.
+func (m *MultiCache[K, V]) loadKey(key K) (V, error) {
+	if batcher := m.batcher.Load(); batcher != nil {
+		return batcher.load(key)
+	}
+	return m.loader(key)
+}
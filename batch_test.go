@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetBatchWindow(t *testing.T) {
+	var (
+		mux     sync.Mutex
+		batches [][]int
+	)
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		mux.Lock()
+		batches = append(batches, append([]int(nil), keys...))
+		mux.Unlock()
+		vals := map[int]int{}
+		for _, key := range keys {
+			if key >= 0 {
+				vals[key] = key * 10
+			}
+		}
+		return vals, nil
+	})
+	mc.SetBatchWindow(20 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := -1; i < 10; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := mc.Get(i)
+			if i < 0 {
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, i*10, val)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, batches, 1, "concurrent misses should be loaded together")
+	sort.Ints(batches[0])
+	assert.Equal(t, []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, batches[0])
+	assert.Equal(t, uint64(11), mc.Stats().Loads)
+
+	assert.Equal(t, 10, mc.MustGet(1))
+	assert.Len(t, batches, 1, "cached values should not be loaded again")
+	assert.Equal(t, 100, mc.MustGet(10))
+	assert.Len(t, batches, 2)
+}
+
+func TestMultiCache_SetBatchWindow_Error(t *testing.T) {
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		return nil, errors.New("unavailable")
+	})
+	mc.SetBatchWindow(time.Millisecond)
+	_, err := mc.Get(1)
+	assert.EqualError(t, err, "unavailable")
+
+	assert.Panics(t, func() {
+		mc.SetBatchWindow(0)
+	})
+	assert.Panics(t, func() {
+		NewMulti[int, int](func(key int) (int, error) {
+			return key, nil
+		}).SetBatchWindow(time.Millisecond)
+	})
+}
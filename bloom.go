@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"hash/maphash"
+	"math"
+	"sync/atomic"
+)
+
+// bloomFilter is a probabilistic set of keys, which may report that a key was added when it wasn't, but never the reverse.
+// A bloomFilter is safe for concurrent use.
+type bloomFilter[K comparable] struct {
+	bits   []atomic.Uint64
+	hashes uint64
+	seed   maphash.Seed
+}
+
+// newBloomFilter creates a bloomFilter sized to hold n keys with the given false positive rate.
+func newBloomFilter[K comparable](n int, falsePositiveRate float64) *bloomFilter[K] {
+	bits := math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Max(1, math.Round(bits/float64(n)*math.Ln2))
+	return &bloomFilter[K]{
+		bits:   make([]atomic.Uint64, (uint64(bits)+63)/64),
+		hashes: uint64(hashes),
+		seed:   maphash.MakeSeed(),
+	}
+}
+
+// empty returns a new, empty bloomFilter with the same size as f.
+func (f *bloomFilter[K]) empty() *bloomFilter[K] {
+	return &bloomFilter[K]{
+		bits:   make([]atomic.Uint64, len(f.bits)),
+		hashes: f.hashes,
+		seed:   maphash.MakeSeed(),
+	}
+}
+
+// locate calls fn with the word and mask of each bit for key, until fn returns false.
+// Bits are derived from two halves of a single hash, which performs about as well as independent hashes.
+func (f *bloomFilter[K]) locate(key K, fn func(word *atomic.Uint64, mask uint64) bool) {
+	var h maphash.Hash
+	h.SetSeed(f.seed)
+	hashKey(&h, key)
+	sum := h.Sum64()
+	h1, h2 := sum&math.MaxUint32, sum>>32
+	size := uint64(len(f.bits)) * 64
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % size
+		if !fn(&f.bits[bit/64], 1<<(bit%64)) {
+			return
+		}
+	}
+}
+
+// add adds key to the filter.
+func (f *bloomFilter[K]) add(key K) {
+	f.locate(key, func(word *atomic.Uint64, mask uint64) bool {
+		for {
+			old := word.Load()
+			if old&mask != 0 || word.CompareAndSwap(old, old|mask) {
+				return true
+			}
+		}
+	})
+}
+
+// contains returns true if key may have been added to the filter.
+func (f *bloomFilter[K]) contains(key K) bool {
+	found := true
+	f.locate(key, func(word *atomic.Uint64, mask uint64) bool {
+		found = word.Load()&mask != 0
+		return found
+	})
+	return found
+}
+
+// SetMissingKeyFilter enables a probabilistic filter of keys that are known not to exist, which is consulted before calling the loader.
+// When the loader reports that a key doesn't exist with [ErrKeyNotFound], or an error reported as absent with SetNegativeTTL, the key is added to the filter.
+// Later requests for the key will return ErrKeyNotFound without calling the loader, which cuts traffic to the underlying data source for workloads with many lookups of keys that don't exist.
+// Unlike SetNegativeTTL, the filter uses a small, fixed amount of memory, regardless of how many missing keys are requested.
+//
+// The filter is sized for expectedKeys missing keys, and will report an existing key as missing at about falsePositiveRate once it holds that many.
+// Because keys can't be removed from the filter, the whole filter is cleared when a key it contains is set with Set or GetOrSet, or invalidated with Invalidate, and when BumpGeneration is called.
+// This method will panic if expectedKeys <= 0, or falsePositiveRate isn't between 0 and 1.
+func (m *MultiCache[K, V]) SetMissingKeyFilter(expectedKeys int, falsePositiveRate float64) {
+	if expectedKeys <= 0 {
+		panic("expected keys <= 0")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("false positive rate must be between 0 and 1")
+	}
+	m.missing.Store(newBloomFilter[K](expectedKeys, falsePositiveRate))
+}
+
+// isMissing returns true if the filter set with SetMissingKeyFilter may contain key.
+func (m *MultiCache[K, V]) isMissing(key K) bool {
+	filter := m.missing.Load()
+	return filter != nil && filter.contains(key)
+}
+
+// addMissing adds key to the filter set with SetMissingKeyFilter, if any.
+func (m *MultiCache[K, V]) addMissing(key K) {
+	if filter := m.missing.Load(); filter != nil {
+		filter.add(key)
+	}
+}
+
+// forgetMissing clears the filter set with SetMissingKeyFilter if it may contain key, since key may exist now.
+func (m *MultiCache[K, V]) forgetMissing(key K) {
+	if filter := m.missing.Load(); filter != nil && filter.contains(key) {
+		m.missing.CompareAndSwap(filter, filter.empty())
+	}
+}
+
+// filterLoader wraps loader so that keys in the filter set with SetMissingKeyFilter return [ErrKeyNotFound] without calling loader, and filterHit is set to true.
+// Keys that loader reports as absent are added to the filter.
+func (m *MultiCache[K, V]) filterLoader(key K, loader LoaderFunc[V], isAbsent func(err error) bool, filterHit *bool) LoaderFunc[V] {
+	return func() (V, error) {
+		if m.isMissing(key) {
+			*filterHit = true
+			var mt V
+			return mt, ErrKeyNotFound
+		}
+		val, err := loader()
+		if err != nil && (IsKeyNotFound(err) || (isAbsent != nil && isAbsent(err))) {
+			m.addMissing(key)
+		}
+		return val, err
+	}
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	filter := newBloomFilter[string](1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		filter.add(fmt.Sprintf("missing-%d", i))
+	}
+	for i := 0; i < 1000; i++ {
+		require.True(t, filter.contains(fmt.Sprintf("missing-%d", i)))
+	}
+	var falsePositives int
+	for i := 0; i < 10000; i++ {
+		if filter.contains(fmt.Sprintf("present-%d", i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 300, "False positive rate should be close to 1%")
+	assert.False(t, filter.empty().contains("missing-0"))
+}
+
+func TestMultiCache_SetMissingKeyFilter(t *testing.T) {
+	var calls atomic.Int32
+	mc := NewMultiOptional[int, string](func(key int) (string, bool, error) {
+		calls.Add(1)
+		if key < 0 {
+			return "", false, nil
+		}
+		return fmt.Sprint(key), true, nil
+	})
+	mc.SetMissingKeyFilter(100, 0.01)
+
+	for i := 0; i < 3; i++ {
+		_, err := mc.Get(-1)
+		assert.True(t, IsKeyNotFound(err))
+	}
+	assert.Equal(t, int32(1), calls.Load())
+	stats := mc.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+
+	assert.Equal(t, "1", mc.MustGet(1))
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Setting a missing key means that it exists now.
+	mc.Set(-1, "created")
+	assert.Equal(t, "created", mc.MustGet(-1))
+	mc.Invalidate(-1)
+	_, err := mc.Get(-1)
+	assert.True(t, IsKeyNotFound(err))
+	assert.Equal(t, int32(3), calls.Load())
+
+	assert.Panics(t, func() {
+		mc.SetMissingKeyFilter(0, 0.01)
+	})
+	assert.Panics(t, func() {
+		mc.SetMissingKeyFilter(10, 1)
+	})
+}
+
+func TestMultiCache_SetMissingKeyFilter_Batch(t *testing.T) {
+	var requested atomic.Int32
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		requested.Add(int32(len(keys)))
+		vals := map[int]int{}
+		for _, key := range keys {
+			if key%2 == 0 {
+				vals[key] = key
+			}
+		}
+		return vals, nil
+	})
+	mc.SetMissingKeyFilter(100, 0.01)
+
+	vals, err := mc.GetMulti([]int{1, 2, 3, 4})
+	assert.True(t, IsKeyNotFound(err))
+	assert.Equal(t, map[int]int{2: 2, 4: 4}, vals)
+	assert.Equal(t, int32(4), requested.Load())
+
+	vals, err = mc.GetMulti([]int{1, 3, 6})
+	assert.True(t, IsKeyNotFound(err))
+	assert.Equal(t, map[int]int{6: 6}, vals)
+	assert.Equal(t, int32(5), requested.Load())
+}
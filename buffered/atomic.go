@@ -2,14 +2,75 @@ package buffered
 
 import "sync/atomic"
 
-type typedAtomic[T any] struct {
-	val atomic.Value
+// TypedAtomic is a type-safe alternative to [atomic.Value], which holds a value of type T that may be loaded and stored concurrently.
+// The zero value is ready to use, and holds no value until Store, Swap, or CompareAndSwap is called.
+type TypedAtomic[T any] struct {
+	ptr atomic.Pointer[T]
+	// version is incremented after each change to ptr, so copies of the value can be checked for staleness.
+	version atomic.Uint64
 }
 
-func (a *typedAtomic[T]) Load() T {
-	return a.val.Load().(T)
+// Load returns the current value and true, or the zero value of T and false if no value has been stored.
+func (a *TypedAtomic[T]) Load() (T, bool) {
+	p := a.ptr.Load()
+	if p == nil {
+		var mt T
+		return mt, false
+	}
+	return *p, true
 }
 
-func (a *typedAtomic[T]) Store(newVal T) {
-	a.val.Store(newVal)
+// Store sets the current value to val.
+func (a *TypedAtomic[T]) Store(val T) {
+	a.ptr.Store(&val)
+	a.version.Add(1)
+}
+
+// Swap sets the current value to new, and returns the previous value and true, or the zero value of T and false if no value had been stored.
+func (a *TypedAtomic[T]) Swap(new T) (old T, loaded bool) {
+	p := a.ptr.Swap(&new)
+	a.version.Add(1)
+	if p == nil {
+		return old, false
+	}
+	return *p, true
+}
+
+// CompareAndSwap sets the current value to new if it's equal to old, and returns true if the value was swapped.
+// If no value has been stored, then the value is never swapped.
+//
+// Like [atomic.Value.CompareAndSwap], this will panic if T is not comparable.
+func (a *TypedAtomic[T]) CompareAndSwap(old, new T) bool {
+	for {
+		p := a.ptr.Load()
+		if p == nil || any(*p) != any(old) {
+			return false
+		}
+		if a.ptr.CompareAndSwap(p, &new) {
+			a.version.Add(1)
+			return true
+		}
+	}
+}
+
+// load returns the current value, or the zero value of T if no value has been stored.
+func (a *TypedAtomic[T]) load() T {
+	val, _ := a.Load()
+	return val
+}
+
+// stamp returns the current value along with a version that may be passed to isCurrent.
+func (a *TypedAtomic[T]) stamp() (T, uint64) {
+	version := a.version.Load()
+	return a.load(), version
+}
+
+// isCurrent returns true if the value hasn't changed since version was returned from stamp.
+func (a *TypedAtomic[T]) isCurrent(version uint64) bool {
+	return a.version.Load() == version
+}
+
+// retire marks the value as changed after it's removed from a write buffer, so stale copies will be detected.
+func (a *TypedAtomic[T]) retire() {
+	a.version.Add(1)
 }
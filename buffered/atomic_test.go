@@ -0,0 +1,64 @@
+package buffered
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedAtomic(t *testing.T) {
+	var a TypedAtomic[string]
+	val, ok := a.Load()
+	assert.False(t, ok, "The zero value should hold no value")
+	assert.Equal(t, "", val)
+	assert.False(t, a.CompareAndSwap("", "a"), "CompareAndSwap should fail if no value has been stored")
+
+	old, loaded := a.Swap("a")
+	assert.False(t, loaded)
+	assert.Equal(t, "", old)
+
+	a.Store("b")
+	val, ok = a.Load()
+	assert.True(t, ok)
+	assert.Equal(t, "b", val)
+
+	assert.False(t, a.CompareAndSwap("a", "c"))
+	assert.True(t, a.CompareAndSwap("b", "c"))
+	old, loaded = a.Swap("d")
+	assert.True(t, loaded)
+	assert.Equal(t, "c", old)
+}
+
+func TestTypedAtomic_CompareAndSwap_Concurrent(t *testing.T) {
+	var (
+		a  TypedAtomic[int]
+		wg sync.WaitGroup
+	)
+	a.Store(0)
+	wg.Add(8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				for {
+					current, _ := a.Load()
+					if a.CompareAndSwap(current, current+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	val, _ := a.Load()
+	assert.Equal(t, 8000, val)
+}
+
+func TestTypedAtomic_CompareAndSwap_NotComparable(t *testing.T) {
+	var a TypedAtomic[[]int]
+	a.Store([]int{1})
+	assert.Panics(t, func() {
+		a.CompareAndSwap([]int{1}, []int{2})
+	})
+}
@@ -1,41 +1,171 @@
 package buffered
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/saylorsolutions/cache"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+var (
+	_ cache.Cache[string, string] = (*MultiCache[string, string])(nil)
+	_ writeStore[string, string]  = (*cache.MultiCache[string, string])(nil)
+	_ writeStore[string, string]  = (*cache.ShardedMultiCache[string, string])(nil)
+)
+
+// writeStore is implemented by the caches that may be used as the write buffer of a MultiCache.
+type writeStore[K comparable, V any] interface {
+	Get(key K) (V, error)
+	GetCtx(ctx context.Context, key K) (V, error)
+	GetIfPresent(key K) (V, bool)
+	GetOrSet(key K, val V) (V, bool)
+	Set(key K, val V)
+	Remove(key K) (V, bool)
+	OnEvict(fn cache.OnEvictFunc[K, V])
+	SetTTLPolicy(ttl time.Duration)
+	RemoveExpired() int
+	Keys() []K
+	ForEach(fn func(key K, val V) bool)
+	Len() int
+}
+
 // MultiCache provides a double-buffered cache implementation.
 // Reads will use the read cache, and writes are dispatched to the write buffer.
 // Any missed reads will pass through to the write buffer.
 // Setting a value in a MultiCache will invalidate the key in the read cache.
 type MultiCache[K comparable, V any] struct {
-	readCache   *cache.MultiCache[K, V]
-	writeBuffer *cache.MultiCache[K, *typedAtomic[V]]
+	readCache   *cache.MultiCache[K, stamped[V]]
+	writeBuffer writeStore[K, *TypedAtomic[V]]
+	writeBehind atomic.Pointer[writeBehind[K, V]]
+	writeTTL    atomic.Bool
+	stats       stats
+	debounce    atomic.Pointer[debouncer[K]]
+	hooks       atomic.Pointer[bufferedHooks[K, V]]
+	// batch is held for reading by Get, and for writing by SetAll, so readers never observe a partially applied batch.
+	batch sync.RWMutex
+	// stageLock guards staged, which is non-nil while manual flushing is enabled.
+	stageLock sync.Mutex
+	staged    map[K]V
+}
+
+// stamped is a value held in the read cache, along with the write buffer container it was read from, and the container's version at the time.
+// This allows a hit in the read cache to be checked against the write buffer without waiting for an invalidation to propagate.
+type stamped[V any] struct {
+	val     V
+	atom    *TypedAtomic[V]
+	version uint64
 }
 
+// errNotSet is returned by the write buffer of a MultiCache created with NewMulti for keys that haven't been set.
+var errNotSet = errors.New("key not set")
+
 // NewMulti will create a new MultiCache.
 // A MultiCache may be composed of other MultiCache in the case where logical grouping of cached values is needed.
+// Keys that haven't been set will have the zero value of V.
 func NewMulti[K comparable, V any]() *MultiCache[K, V] {
-	buffer := cache.NewMulti[K, *typedAtomic[V]](func(key K) (*typedAtomic[V], error) {
-		val := new(typedAtomic[V])
-		var mt V
-		val.Store(mt)
-		return val, nil
-	})
-	reader := cache.NewMulti[K, V](func(key K) (V, error) {
-		atom, err := buffer.Get(key)
+	return NewMultiWithLoader[K, V](notSetLoader[K, V])
+}
+
+// NewMultiWithLoader is the same as NewMulti, except that keys that haven't been set will be loaded into the write buffer with loader.
+// This makes the MultiCache a read-through cache, while values written with Set still pass through the write buffer.
+// If loading a key fails, then the error will be returned from Get, and the key will be loaded again the next time it's requested.
+//
+// If the loader is nil, then this function will panic.
+func NewMultiWithLoader[K comparable, V any](loader cache.MultiLoaderFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return newMulti[K, V](cache.NewMulti[K, *TypedAtomic[V]](bufferLoader(loader)))
+}
+
+// NewMultiWithLoaderCtx is the same as NewMultiWithLoader, except that the context passed to GetCtx will be passed to loader.
+//
+// If the loader is nil, then this function will panic.
+func NewMultiWithLoaderCtx[K comparable, V any](loader cache.MultiLoaderCtxFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return newMulti[K, V](cache.NewMultiCtx[K, *TypedAtomic[V]](func(ctx context.Context, key K) (*TypedAtomic[V], error) {
+		return newAtom(loader(ctx, key))
+	}))
+}
+
+// NewShardedMulti is the same as NewMulti, except that the write buffer is partitioned across the given number of shards by key hash, each with its own lock.
+// This allows many concurrent writers to different keys to proceed without serializing on a single lock.
+// See [cache.ShardedMultiCache] for details.
+//
+// If shards <= 0, then this function will panic.
+func NewShardedMulti[K comparable, V any](shards int) *MultiCache[K, V] {
+	return NewShardedMultiWithLoader[K, V](shards, notSetLoader[K, V])
+}
+
+// NewShardedMultiWithLoader is the same as NewMultiWithLoader, except that the write buffer is sharded as described in NewShardedMulti.
+//
+// If shards <= 0, or the loader is nil, then this function will panic.
+func NewShardedMultiWithLoader[K comparable, V any](shards int, loader cache.MultiLoaderFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return newMulti[K, V](cache.NewShardedMulti[K, *TypedAtomic[V]](shards, bufferLoader(loader)))
+}
+
+func notSetLoader[K comparable, V any](_ K) (V, error) {
+	var mt V
+	return mt, errNotSet
+}
+
+// bufferLoader adapts loader to load values into containers in the write buffer.
+func bufferLoader[K comparable, V any](loader cache.MultiLoaderFunc[K, V]) cache.MultiLoaderFunc[K, *TypedAtomic[V]] {
+	return func(key K) (*TypedAtomic[V], error) {
+		return newAtom(loader(key))
+	}
+}
+
+// newAtom returns a container for a value loaded into the write buffer, or err if loading failed.
+func newAtom[V any](loaded V, err error) (*TypedAtomic[V], error) {
+	if err != nil {
+		return nil, err
+	}
+	val := new(TypedAtomic[V])
+	val.Store(loaded)
+	return val, nil
+}
+
+func newMulti[K comparable, V any](buffer writeStore[K, *TypedAtomic[V]]) *MultiCache[K, V] {
+	reader := cache.NewMultiCtx[K, stamped[V]](func(ctx context.Context, key K) (stamped[V], error) {
+		atom, err := buffer.GetCtx(ctx, key)
+		if errors.Is(err, errNotSet) {
+			return stamped[V]{}, nil
+		}
 		if err != nil {
-			var mt V
-			return mt, err
+			return stamped[V]{}, err
 		}
-		return atom.Load(), nil
+		val, version := atom.stamp()
+		return stamped[V]{val: val, atom: atom, version: version}, nil
 	})
-	return &MultiCache[K, V]{
+	m := &MultiCache[K, V]{
 		readCache:   reader,
 		writeBuffer: buffer,
 	}
+	buffer.OnEvict(m.evicted)
+	return m
+}
+
+// atom returns the write buffer's container for the value of key, and loads it if needed.
+// Keys that haven't been set in a MultiCache created with NewMulti are added with the zero value.
+func (m *MultiCache[K, V]) atom(key K) (*TypedAtomic[V], error) {
+	atom, err := m.writeBuffer.Get(key)
+	if errors.Is(err, errNotSet) {
+		var mt V
+		atom = new(TypedAtomic[V])
+		atom.Store(mt)
+		atom, _ = m.writeBuffer.GetOrSet(key, atom)
+		return atom, nil
+	}
+	return atom, err
 }
 
 // Preheat will load the values associated with each key in keys into the read cache.
@@ -50,10 +180,76 @@ func (m *MultiCache[K, V]) Preheat(keys []K) error {
 	return nil
 }
 
+// PreheatFrom will seed both the write buffer and the read cache with every value in vals by its key, in one pass.
+// This is useful for loading initial state at startup, since the read cache is populated directly rather than being invalidated and loaded again for each key.
+// Like SetAll, the whole batch becomes visible to readers at once.
+//
+// Values are applied immediately, even if manual flushing is enabled, and aren't flushed by write-behind since they're assumed to come from the backing store.
+func (m *MultiCache[K, V]) PreheatFrom(vals map[K]V) {
+	m.batch.Lock()
+	defer m.batch.Unlock()
+	for key, val := range vals {
+		atom := m.store(key, val)
+		m.readCache.Set(key, stamped[V]{val: val, atom: atom, version: atom.version.Load()})
+	}
+}
+
 // Get will return the value in the read cache associated with key K.
 // Any errors returned from [cache.Value.Get] will be returned from Get.
+//
+// Each value in the read cache is stamped with the version of the write that produced it, and the version is checked on every hit.
+// This means that Get will never return a value that was replaced before Get was called, even if the replacing write hasn't invalidated the read cache yet.
+// The check is skipped while an invalidation window is set with SetInvalidationWindow, since that allows readers to observe older values.
 func (m *MultiCache[K, V]) Get(key K) (V, error) {
-	return m.readCache.Get(key)
+	return m.GetCtx(context.Background(), key)
+}
+
+// GetCtx is the same as Get, except that ctx will be passed to the loader if the MultiCache was created with NewMultiWithLoaderCtx.
+// If ctx is already done, then its error is returned without reading the MultiCache.
+func (m *MultiCache[K, V]) GetCtx(ctx context.Context, key K) (V, error) {
+	if err := ctx.Err(); err != nil {
+		var mt V
+		return mt, err
+	}
+	m.batch.RLock()
+	defer m.batch.RUnlock()
+	for {
+		s, err := m.readCache.GetCtx(ctx, key)
+		if err != nil || m.debounce.Load() != nil || m.isCurrent(key, s) {
+			return s.val, err
+		}
+		m.Invalidate(key)
+	}
+}
+
+// isCurrent returns true if s still reflects the value of key in the write buffer.
+func (m *MultiCache[K, V]) isCurrent(key K, s stamped[V]) bool {
+	if s.atom == nil {
+		// The key wasn't set when s was read, so it's current until a value is added to the write buffer.
+		_, ok := m.writeBuffer.GetIfPresent(key)
+		return !ok
+	}
+	return s.atom.isCurrent(s.version)
+}
+
+// Lookup will return the value associated with key and true if it has been set, or loaded by a MultiCache created with NewMultiWithLoader.
+// Unlike Get, this will never load a value, so a stored zero value can be distinguished from a key that was never set.
+func (m *MultiCache[K, V]) Lookup(key K) (V, bool) {
+	m.batch.RLock()
+	defer m.batch.RUnlock()
+	atom, ok := m.writeBuffer.GetIfPresent(key)
+	if !ok {
+		var mt V
+		return mt, false
+	}
+	return atom.load(), true
+}
+
+// Contains returns true if a value for key has been set, or loaded by a MultiCache created with NewMultiWithLoader.
+// See Lookup for details.
+func (m *MultiCache[K, V]) Contains(key K) bool {
+	_, ok := m.Lookup(key)
+	return ok
 }
 
 // MustGet does the same thing as Get, but it will panic if an error occurs.
@@ -67,6 +263,7 @@ func (m *MultiCache[K, V]) MustGet(key K) V {
 
 // Invalidate will invalidate the cache.Value related to key K, if it exists.
 func (m *MultiCache[K, V]) Invalidate(key K) {
+	m.stats.invalidations.Add(1)
 	m.readCache.Invalidate(key)
 }
 
@@ -78,17 +275,97 @@ func (m *MultiCache[K, V]) OnInvalidate(key K, invalidateFunc cache.OnInvalidate
 
 // Set will set the key in the write buffer to the assigned value.
 // This implicitly invalidates the same key in the read cache.
+// If manual flushing is enabled with SetManualFlush, then the value is staged until Flush is called.
 func (m *MultiCache[K, V]) Set(key K, val V) {
-	atom := m.writeBuffer.MustGet(key)
+	if m.stage(map[K]V{key: val}) {
+		return
+	}
+	m.store(key, val)
+	m.invalidateWrite(key)
+	m.afterWrite(key, val)
+}
+
+// SetCtx is the same as Set, except that the value isn't set if ctx is already done, and ctx's error is returned instead.
+// This allows a write that's part of a request to be abandoned once the request's deadline has passed.
+func (m *MultiCache[K, V]) SetCtx(ctx context.Context, key K, val V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.Set(key, val)
+	return nil
+}
+
+// store sets the value for key in the write buffer, without loading its previous value, and returns its container.
+func (m *MultiCache[K, V]) store(key K, val V) *TypedAtomic[V] {
+	m.stats.writes.Add(1)
+	atom := new(TypedAtomic[V])
 	atom.Store(val)
-	m.Invalidate(key)
+	if existing, loaded := m.writeBuffer.GetOrSet(key, atom); loaded {
+		existing.Store(val)
+		m.touch(key, existing)
+		return existing
+	}
+	return atom
+}
+
+// SetAll will set each key in vals to its value, as if each were set with Set.
+// The whole batch becomes visible to readers at once, so a reader that observes any value from vals will observe every other value from vals in later reads.
+// This is useful for related keys that must remain consistent with each other.
+//
+// Get will wait while a batch is being applied, so SetAll should be reserved for writes that must be atomic.
+// If manual flushing is enabled with SetManualFlush, then the values are staged until Flush is called.
+func (m *MultiCache[K, V]) SetAll(vals map[K]V) {
+	if m.stage(vals) {
+		return
+	}
+	m.apply(vals)
+}
+
+// apply sets each key in vals as a single batch.
+func (m *MultiCache[K, V]) apply(vals map[K]V) {
+	m.batch.Lock()
+	for key, val := range vals {
+		m.store(key, val)
+		m.Invalidate(key)
+	}
+	m.batch.Unlock()
+	for key, val := range vals {
+		m.afterWrite(key, val)
+	}
 }
 
 // Unset will clear a value referenced by key in the MultiCache.
 // Which means the next Get call for the same key will return the default value for V.
+// If the value hasn't been flushed by write-behind yet, then it won't be.
 func (m *MultiCache[K, V]) Unset(key K) {
-	m.writeBuffer.Invalidate(key)
-	m.readCache.Invalidate(key)
+	m.Delete(key)
+}
+
+// Delete is the same as Unset, except that it returns the value that was held in the write buffer for key, and true if there was one.
+// The returned value may have expired, but it's still reported since it's what was held by the write buffer.
+// This is useful for write-back workflows, where a deleted value may still need to be persisted.
+// A value staged with manual flushing is discarded without being reported.
+func (m *MultiCache[K, V]) Delete(key K) (V, bool) {
+	m.unstage(key)
+	if wb := m.writeBehind.Load(); wb != nil {
+		wb.forget(key)
+	}
+	atom, ok := m.writeBuffer.Remove(key)
+	if ok {
+		atom.retire()
+	}
+	m.Invalidate(key)
+	if !ok {
+		var mt V
+		return mt, false
+	}
+	return atom.load(), true
+}
+
+// SetEventHandler sets a handler that will receive a [cache.Event] for each hit, miss, load, invalidation, and eviction in the read cache.
+// See [cache.MultiCache.SetEventHandler] for details.
+func (m *MultiCache[K, V]) SetEventHandler(source string, handler cache.EventHandler) {
+	m.readCache.SetEventHandler(source, handler)
 }
 
 // SetTTLPolicy sets the time to live policy for all read cache values after they are retrieved.
@@ -2,9 +2,12 @@ package buffered
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -37,7 +40,7 @@ func TestMultiCache_Set(t *testing.T) {
 	var (
 		wg          sync.WaitGroup
 		ctx, cancel = context.WithTimeout(context.Background(), 3*time.Second)
-		hitChange   bool
+		hitChange   atomic.Bool
 	)
 	defer cancel()
 
@@ -58,7 +61,7 @@ func TestMultiCache_Set(t *testing.T) {
 					assert.NoError(t, err)
 					if val != key {
 						t.Logf("Goroutine %d got %d instead of %d after %s", i, val, key, time.Since(start))
-						hitChange = true
+						hitChange.Store(true)
 						return
 					}
 				}
@@ -72,5 +75,178 @@ func TestMultiCache_Set(t *testing.T) {
 	mc.Set(key, -1)
 	t.Logf("Spent %s setting", time.Since(start))
 	wg.Wait()
-	assert.True(t, hitChange, "Consumers should have seen a change dispatched after a new value was set")
+	assert.True(t, hitChange.Load(), "Consumers should have seen a change dispatched after a new value was set")
+}
+
+func TestNewMultiWithLoader(t *testing.T) {
+	var loads atomic.Int32
+	mc := NewMultiWithLoader[int, int](func(key int) (int, error) {
+		loads.Add(1)
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key * 10, nil
+	})
+
+	assert.Equal(t, 10, mc.MustGet(1))
+	assert.Equal(t, 10, mc.MustGet(1))
+	assert.Equal(t, int32(1), loads.Load())
+
+	mc.Set(1, 5)
+	assert.Equal(t, 5, mc.MustGet(1))
+	assert.Equal(t, int32(1), loads.Load(), "A set value should not be loaded")
+
+	_, err := mc.Get(-1)
+	assert.Error(t, err)
+	_, err = mc.Get(-1)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), loads.Load(), "A failed load should be retried")
+
+	assert.Panics(t, func() {
+		NewMultiWithLoader[int, int](nil)
+	})
+}
+
+func TestMultiCache_SetAll(t *testing.T) {
+	const numKeys = 10
+	mc := NewMulti[int, int]()
+	initial := map[int]int{}
+	for i := 0; i < numKeys; i++ {
+		initial[i] = 0
+	}
+	mc.SetAll(initial)
+
+	var (
+		wg   sync.WaitGroup
+		done atomic.Bool
+	)
+	wg.Add(4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer wg.Done()
+			for !done.Load() {
+				// Once a reader observes a generation, every later read must be at least as new.
+				var last int
+				for key := 0; key < numKeys; key++ {
+					val := mc.MustGet(key)
+					if !assert.GreaterOrEqual(t, val, last, "Observed a partially applied batch") {
+						return
+					}
+					last = val
+				}
+			}
+		}()
+	}
+	for gen := 1; gen <= 2000; gen++ {
+		batch := map[int]int{}
+		for key := 0; key < numKeys; key++ {
+			batch[key] = gen
+		}
+		mc.SetAll(batch)
+	}
+	done.Store(true)
+	wg.Wait()
+	assert.Equal(t, 2000, mc.MustGet(numKeys-1))
+}
+
+func TestMultiCache_Lookup(t *testing.T) {
+	mc := NewMulti[string, int]()
+	val, ok := mc.Lookup("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, 0, mc.MustGet("a"))
+	assert.False(t, mc.Contains("a"), "Get should not make a key present")
+
+	mc.Set("a", 0)
+	val, ok = mc.Lookup("a")
+	assert.True(t, ok)
+	assert.Equal(t, 0, val)
+	assert.True(t, mc.Contains("a"))
+
+	mc.Unset("a")
+	assert.False(t, mc.Contains("a"))
+}
+
+func TestMultiCache_Delete(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.Set("a", 5)
+	assert.Equal(t, 5, mc.MustGet("a"))
+
+	val, ok := mc.Delete("a")
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+	assert.Equal(t, 0, mc.MustGet("a"), "Delete should invalidate the read cache")
+
+	val, ok = mc.Delete("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+	_, ok = mc.Delete("never-set")
+	assert.False(t, ok)
+}
+
+func TestMultiCache_PreheatFrom(t *testing.T) {
+	mc := NewMulti[string, int]()
+	var flushed atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mc.SetWriteBehind(ctx, func(key string, val int) error {
+		flushed.Add(1)
+		return nil
+	}, time.Hour, 0)
+
+	mc.PreheatFrom(map[string]int{"a": 1, "b": 2})
+	assert.ElementsMatch(t, []string{"a", "b"}, mc.CachedKeys(), "The read cache should be seeded directly")
+	assert.ElementsMatch(t, []string{"a", "b"}, mc.Keys())
+	assert.Equal(t, 2, mc.MustGet("b"))
+	stats := mc.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(0), stats.Misses)
+	assert.Equal(t, uint64(0), stats.Invalidations)
+
+	require.NoError(t, mc.Persist())
+	assert.Equal(t, int32(0), flushed.Load(), "Preheated values should not be flushed")
+}
+
+func TestMultiCache_Get_Versioned(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.Set("a", 1)
+	assert.Equal(t, 1, mc.MustGet("a"))
+
+	// Simulate a reader running between a write to the buffer and the invalidation of the read cache.
+	mc.store("a", 2)
+	assert.Equal(t, 2, mc.MustGet("a"), "A stale read cache value should be detected")
+
+	assert.Equal(t, 0, mc.MustGet("b"))
+	mc.store("b", 3)
+	assert.Equal(t, 3, mc.MustGet("b"), "A key set after a read of its zero value should be detected")
+
+	mc.SetInvalidationWindow(time.Hour)
+	mc.store("a", 4)
+	assert.Equal(t, 2, mc.MustGet("a"), "Version checks should be skipped with an invalidation window")
+}
+
+func TestMultiCache_Get_VersionedConcurrent(t *testing.T) {
+	mc := NewMulti[int, int]()
+	mc.Set(0, 0)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 10000; i++ {
+			mc.Set(0, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		var last int
+		for i := 0; i < 10000; i++ {
+			val := mc.MustGet(0)
+			if !assert.GreaterOrEqual(t, val, last, "Reads should never go backwards") {
+				return
+			}
+			last = val
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, 10000, mc.MustGet(0))
 }
@@ -0,0 +1,45 @@
+package buffered
+
+// CompareAndSwap will set the value for key to new if its current value is equal to old, and returns true if the value was swapped.
+// This allows concurrent writers updating the same key, such as a counter, to coordinate without an external mutex.
+// Like Set, a successful swap invalidates the key in the read cache.
+//
+// If key hasn't been set, then its current value is loaded in the same way as Get, and an error is returned if it can't be loaded.
+func CompareAndSwap[K comparable, V comparable](m *MultiCache[K, V], key K, old, new V) (bool, error) {
+	atom, err := m.atom(key)
+	if err != nil {
+		return false, err
+	}
+	if !atom.CompareAndSwap(old, new) {
+		return false, nil
+	}
+	m.stats.writes.Add(1)
+	m.touch(key, atom)
+	m.invalidateWrite(key)
+	m.afterWrite(key, new)
+	return true, nil
+}
+
+// Update will atomically replace the value for key with the result of fn, and returns the new value.
+// fn is called with the current value, and may be called more than once if another writer changes the value concurrently, so it must not have side effects.
+//
+// If key hasn't been set, then its current value is loaded in the same way as Get, and an error is returned if it can't be loaded.
+func Update[K comparable, V comparable](m *MultiCache[K, V], key K, fn func(current V) V) (V, error) {
+	for {
+		atom, err := m.atom(key)
+		if err != nil {
+			var mt V
+			return mt, err
+		}
+		current := atom.load()
+		updated := fn(current)
+		swapped, err := CompareAndSwap(m, key, current, updated)
+		if err != nil {
+			var mt V
+			return mt, err
+		}
+		if swapped {
+			return updated, nil
+		}
+	}
+}
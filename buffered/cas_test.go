@@ -0,0 +1,67 @@
+package buffered
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAndSwap(t *testing.T) {
+	mc := NewMulti[string, int]()
+	assert.Equal(t, 0, mc.MustGet("counter"))
+
+	swapped, err := CompareAndSwap(mc, "counter", 0, 1)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+	assert.Equal(t, 1, mc.MustGet("counter"), "A swap should invalidate the read cache")
+
+	swapped, err = CompareAndSwap(mc, "counter", 0, 2)
+	require.NoError(t, err)
+	assert.False(t, swapped)
+	assert.Equal(t, 1, mc.MustGet("counter"))
+}
+
+func TestUpdate(t *testing.T) {
+	const (
+		writers    = 8
+		increments = 500
+	)
+	mc := NewMulti[string, int]()
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				_, err := Update(mc, "counter", func(current int) int {
+					return current + 1
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, writers*increments, mc.MustGet("counter"))
+}
+
+func TestCompareAndSwap_LoadError(t *testing.T) {
+	mc := NewMultiWithLoader[string, int](func(key string) (int, error) {
+		return 0, errors.New("unavailable")
+	})
+	_, err := CompareAndSwap(mc, "a", 0, 1)
+	assert.Error(t, err)
+	_, err = Update(mc, "a", func(current int) int {
+		return current + 1
+	})
+	assert.Error(t, err)
+
+	// Set doesn't need the previous value, so it shouldn't load it.
+	mc.Set("a", 5)
+	swapped, err := CompareAndSwap(mc, "a", 5, 6)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+	assert.Equal(t, 6, mc.MustGet("a"))
+}
@@ -0,0 +1,74 @@
+package buffered
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+func TestMultiCache_GetCtx(t *testing.T) {
+	mc := NewMultiWithLoaderCtx[string, string](func(ctx context.Context, key string) (string, error) {
+		val, _ := ctx.Value(ctxKey{}).(string)
+		return key + ":" + val, nil
+	})
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	val, err := mc.GetCtx(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a:request", val, "The context should be passed to the loader")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = mc.GetCtx(canceled, "b")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, mc.Contains("b"), "A canceled request should not load a value")
+
+	assert.ErrorIs(t, mc.SetCtx(canceled, "a", "canceled"), context.Canceled)
+	require.NoError(t, mc.SetCtx(ctx, "a", "set"))
+	assert.Equal(t, "set", mc.MustGet("a"))
+}
+
+func TestMultiCache_Close(t *testing.T) {
+	var (
+		mux     sync.Mutex
+		flushed = map[string]int{}
+	)
+	mc := NewMulti[string, int]()
+	mc.SetWriteBehind(context.Background(), func(key string, val int) error {
+		mux.Lock()
+		defer mux.Unlock()
+		flushed[key] = val
+		return nil
+	}, time.Hour, 0)
+	mc.SetAll(map[string]int{"a": 1, "b": 2})
+
+	require.NoError(t, mc.Close(context.Background()))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, flushed, "Close should drain pending writes")
+	require.NoError(t, mc.Close(context.Background()), "Closing again should be safe")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mc.Set("c", 3)
+	assert.ErrorIs(t, mc.Close(ctx), context.Canceled, "Writes left pending should be reported")
+	assert.NotContains(t, flushed, "c")
+	require.NoError(t, mc.Persist())
+	assert.Equal(t, 3, flushed["c"], "Writes left pending by Close should still be flushed by Persist")
+
+	require.NoError(t, NewMulti[string, int]().Close(ctx), "Close should do nothing without write-behind")
+}
+
+func TestMultiCache_Close_FlushError(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	mc := NewMulti[string, int]()
+	mc.SetWriteBehind(context.Background(), func(key string, val int) error {
+		return errFlush
+	}, time.Hour, 0)
+	mc.Set("a", 1)
+	assert.ErrorIs(t, mc.Close(context.Background()), errFlush)
+}
@@ -0,0 +1,70 @@
+package buffered
+
+import (
+	"sync"
+	"time"
+)
+
+// SetInvalidationWindow coalesces the read cache invalidations caused by writing the same key with Set, CompareAndSwap, or Update.
+// The first write to a key invalidates it immediately, and further writes within window are applied to the write buffer, but only invalidate the key once the window has elapsed.
+// This means a key is invalidated at most once per window, so a writer setting the same key many times per second doesn't force readers to repeatedly miss the read cache.
+// Readers may observe a value that's up to window out of date.
+//
+// SetAll, Flush, Unset, and Delete always invalidate immediately.
+// This method will panic if window <= 0.
+func (m *MultiCache[K, V]) SetInvalidationWindow(window time.Duration) {
+	if window <= 0 {
+		panic("invalidation window <= 0")
+	}
+	m.debounce.Store(&debouncer[K]{
+		window:  window,
+		windows: map[K]*debounceWindow{},
+	})
+}
+
+// invalidateWrite invalidates key after it was written, coalescing invalidations if an invalidation window is set.
+func (m *MultiCache[K, V]) invalidateWrite(key K) {
+	d := m.debounce.Load()
+	if d == nil {
+		m.Invalidate(key)
+		return
+	}
+	d.mux.Lock()
+	if w, ok := d.windows[key]; ok {
+		w.dirty = true
+		d.mux.Unlock()
+		return
+	}
+	d.open(key, m.Invalidate)
+	d.mux.Unlock()
+	m.Invalidate(key)
+}
+
+type debouncer[K comparable] struct {
+	window  time.Duration
+	mux     sync.Mutex
+	windows map[K]*debounceWindow
+}
+
+type debounceWindow struct {
+	dirty bool
+}
+
+// open starts a window for key, during which writes only mark the key dirty.
+// When the window elapses, a dirty key is invalidated and a new window is started, otherwise the window is closed.
+// This must be called while holding the debouncer's lock.
+func (d *debouncer[K]) open(key K, invalidate func(key K)) {
+	w := new(debounceWindow)
+	d.windows[key] = w
+	time.AfterFunc(d.window, func() {
+		d.mux.Lock()
+		delete(d.windows, key)
+		if !w.dirty {
+			d.mux.Unlock()
+			return
+		}
+		d.open(key, invalidate)
+		d.mux.Unlock()
+		invalidate(key)
+	})
+}
@@ -0,0 +1,35 @@
+package buffered
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetInvalidationWindow(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.SetInvalidationWindow(50 * time.Millisecond)
+
+	mc.Set("a", 1)
+	assert.Equal(t, 1, mc.MustGet("a"), "The first write should invalidate immediately")
+	for i := 2; i <= 100; i++ {
+		mc.Set("a", i)
+	}
+	assert.Equal(t, 1, mc.MustGet("a"), "Writes within the window should not invalidate the read cache")
+	val, _ := mc.Lookup("a")
+	assert.Equal(t, 100, val, "Writes should still be applied to the write buffer")
+	assert.Equal(t, uint64(1), mc.Stats().Invalidations)
+
+	assert.Eventually(t, func() bool {
+		return mc.MustGet("a") == 100
+	}, time.Second, 5*time.Millisecond, "The key should be invalidated once the window elapses")
+	assert.Equal(t, uint64(2), mc.Stats().Invalidations)
+
+	mc.SetAll(map[string]int{"a": 101})
+	assert.Equal(t, 101, mc.MustGet("a"), "SetAll should invalidate immediately")
+
+	assert.Panics(t, func() {
+		mc.SetInvalidationWindow(0)
+	})
+}
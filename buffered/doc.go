@@ -7,7 +7,26 @@ A buffered MultiCache will maintain an internal read cache and write buffer.
 If there's a cache miss on a [MultiCache.Get], then the read cache will pass through to the write buffer.
 The write buffer is where a new value is set with [MultiCache.Set].
 This implicitly invalidates the matching key in the read cache.
+Each value in the read cache is stamped with the version of the write that produced it, so a reader will never observe a replaced value while an invalidation is still propagating.
+Related keys that must change together may be written with [MultiCache.SetAll], which makes the whole batch visible to readers at once.
+Updates that are computed over several steps may instead be staged in a [Tx] with [MultiCache.Begin] or [MultiCache.Transact], and published or discarded together.
+A writer that sets the same key many times per second can limit how often it's invalidated with [MultiCache.SetInvalidationWindow].
+High frequency writers may also defer visibility with [MultiCache.SetManualFlush], and apply staged writes at a chosen moment with [MultiCache.Flush].
+Concurrent writers to the same key, such as a counter, can coordinate with [CompareAndSwap] or [Update] instead of an external mutex.
 
 With this setup and good cache hit rates, there can be near zero contention between reading and writing values.
+Many concurrent writers to different keys can avoid contending with each other by sharding the write buffer with [NewShardedMulti].
+Initial state may be seeded into both the write buffer and the read cache in one pass with [MultiCache.PreheatFrom].
+The contents of the write buffer may be enumerated with [MultiCache.Keys] or [MultiCache.ForEach].
+The tradeoff can be measured with [MultiCache.Stats], which reports read cache hits and misses along with writes and invalidations.
+The same design is applied to a single value, such as a frequently read configuration object, with [Value].
+Values in the write buffer are held in a [TypedAtomic], which may also be used on its own as a type-safe alternative to atomic.Value.
+
+By default, keys that haven't been set have the zero value, and [MultiCache.Lookup] or [MultiCache.Contains] may be used to tell them apart from a stored zero value.
+A MultiCache created with [NewMultiWithLoader] will instead load missing keys into the write buffer with a [cache.MultiLoaderFunc].
+Values in the write buffer never expire unless [MultiCache.SetWriteTTL] is used, which keeps abandoned keys from being held forever.
+The write path may be observed with [MultiCache.OnSet] and [MultiCache.OnEvict], such as for replication or audit logging.
+To persist values written with Set to a backing store, [MultiCache.SetWriteBehind] will flush them in the background with a [FlushFunc].
+Pending writes are drained for a graceful shutdown with [MultiCache.Close], and [MultiCache.GetCtx] and [MultiCache.SetCtx] respect request deadlines.
 */
 package buffered
@@ -0,0 +1,65 @@
+package buffered
+
+// SetManualFlush controls whether values written with Set and SetAll become visible to readers immediately, or only once Flush is called.
+// While enabled, writes are staged without touching the write buffer or invalidating the read cache, so a high frequency writer can batch the visibility of many writes instead of invalidating the read cache on every Set.
+// A later write to a staged key replaces the staged value.
+//
+// Disabling manual flushing will flush any staged values.
+// Note that Unset, CompareAndSwap, and Update always apply immediately, and Unset discards a staged value for the same key.
+func (m *MultiCache[K, V]) SetManualFlush(enabled bool) {
+	m.stageLock.Lock()
+	defer m.stageLock.Unlock()
+	if enabled {
+		if m.staged == nil {
+			m.staged = map[K]V{}
+		}
+		return
+	}
+	m.flushStaged()
+	m.staged = nil
+}
+
+// Flush will apply all values staged since the last Flush, as if they were written with SetAll.
+// The whole batch becomes visible to readers at once.
+// If manual flushing isn't enabled with SetManualFlush, then there's nothing to flush and this does nothing.
+func (m *MultiCache[K, V]) Flush() {
+	m.stageLock.Lock()
+	defer m.stageLock.Unlock()
+	m.flushStaged()
+}
+
+// Staged returns the number of values waiting to be applied by Flush.
+func (m *MultiCache[K, V]) Staged() int {
+	m.stageLock.Lock()
+	defer m.stageLock.Unlock()
+	return len(m.staged)
+}
+
+// stage will stage vals to be applied by Flush, and returns false if manual flushing isn't enabled.
+func (m *MultiCache[K, V]) stage(vals map[K]V) bool {
+	m.stageLock.Lock()
+	defer m.stageLock.Unlock()
+	if m.staged == nil {
+		return false
+	}
+	for key, val := range vals {
+		m.staged[key] = val
+	}
+	return true
+}
+
+// unstage discards any staged value for key.
+func (m *MultiCache[K, V]) unstage(key K) {
+	m.stageLock.Lock()
+	defer m.stageLock.Unlock()
+	delete(m.staged, key)
+}
+
+// flushStaged must be called while holding stageLock, so staged batches are applied in order.
+func (m *MultiCache[K, V]) flushStaged() {
+	if len(m.staged) == 0 {
+		return
+	}
+	m.apply(m.staged)
+	m.staged = map[K]V{}
+}
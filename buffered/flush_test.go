@@ -0,0 +1,41 @@
+package buffered
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Flush(t *testing.T) {
+	c := NewMulti[string, int]()
+	c.Set("a", 1)
+	assert.Equal(t, 1, c.MustGet("a"))
+
+	c.SetManualFlush(true)
+	c.Set("a", 2)
+	c.SetAll(map[string]int{"b": 3, "c": 4})
+	c.Set("b", 5)
+	assert.Equal(t, 3, c.Staged())
+	assert.Equal(t, 1, c.MustGet("a"), "Staged values should not be visible before Flush")
+	assert.Equal(t, 0, c.MustGet("b"))
+	assert.False(t, c.Contains("c"))
+
+	c.Flush()
+	assert.Equal(t, 0, c.Staged())
+	assert.Equal(t, 2, c.MustGet("a"))
+	assert.Equal(t, 5, c.MustGet("b"), "The last staged value should win")
+	assert.Equal(t, 4, c.MustGet("c"))
+
+	c.Set("c", 6)
+	c.Set("d", 7)
+	c.Unset("d")
+	assert.Equal(t, 1, c.Staged(), "Unset should discard the staged value")
+	c.SetManualFlush(false)
+	assert.Equal(t, 0, c.Staged())
+	assert.Equal(t, 6, c.MustGet("c"), "Disabling manual flushing should flush staged values")
+	assert.False(t, c.Contains("d"))
+
+	c.Set("c", 8)
+	assert.Equal(t, 8, c.MustGet("c"))
+	c.Flush()
+}
@@ -0,0 +1,79 @@
+package buffered
+
+import (
+	"github.com/saylorsolutions/cache"
+)
+
+// OnSetFunc is a function that will be called with each value written to a MultiCache.
+type OnSetFunc[K comparable, V any] func(key K, val V)
+
+type bufferedHooks[K comparable, V any] struct {
+	onSet   OnSetFunc[K, V]
+	onEvict cache.OnEvictFunc[K, V]
+}
+
+// OnSet sets a function that will be called with the key and value of every write applied to the write buffer with Set, SetAll, Flush, CompareAndSwap, or Update.
+// This allows replication, audit logging, or metrics to observe the write path.
+// Values staged with manual flushing are reported once they're flushed, and values seeded with PreheatFrom aren't reported.
+//
+// The function is called after the write is applied, without holding any locks, so it may use the MultiCache.
+func (m *MultiCache[K, V]) OnSet(fn OnSetFunc[K, V]) {
+	m.updateHooks(func(hooks *bufferedHooks[K, V]) {
+		hooks.onSet = fn
+	})
+}
+
+// OnEvict sets a function that will be called with the key and value of every value dropped from the write buffer, along with the [cache.Reason] it was dropped.
+// Values removed with Unset or Delete are reported with [cache.ReasonRemoved], and values that expired after SetWriteTTL are reported with [cache.ReasonExpired] once they're removed by RemoveExpired.
+//
+// The function is called without holding any locks, so it may use the MultiCache.
+func (m *MultiCache[K, V]) OnEvict(fn cache.OnEvictFunc[K, V]) {
+	m.updateHooks(func(hooks *bufferedHooks[K, V]) {
+		hooks.onEvict = fn
+	})
+}
+
+func (m *MultiCache[K, V]) updateHooks(update func(hooks *bufferedHooks[K, V])) {
+	for {
+		current := m.hooks.Load()
+		updated := new(bufferedHooks[K, V])
+		if current != nil {
+			*updated = *current
+		}
+		update(updated)
+		if m.hooks.CompareAndSwap(current, updated) {
+			return
+		}
+	}
+}
+
+// afterWrite reports a write of val to key after it has been applied to the write buffer.
+func (m *MultiCache[K, V]) afterWrite(key K, val V) {
+	if wb := m.writeBehind.Load(); wb != nil {
+		wb.markPending(key)
+	}
+	if hooks := m.hooks.Load(); hooks != nil && hooks.onSet != nil {
+		hooks.onSet(key, val)
+	}
+}
+
+// evicted is called by the write buffer with each value it drops.
+func (m *MultiCache[K, V]) evicted(key K, atom *TypedAtomic[V], reason cache.Reason) {
+	if atom == nil {
+		return
+	}
+	if reason == cache.ReasonReplaced {
+		// A replaced value was overwritten by a write to the same key, which is reported with OnSet instead.
+		// A write may have replaced an expired container with a new one, so copies of the old container's value must be detected as stale.
+		if current, ok := m.writeBuffer.GetIfPresent(key); !ok || current != atom {
+			atom.retire()
+		}
+		return
+	}
+	if reason == cache.ReasonExpired {
+		m.Invalidate(key)
+	}
+	if hooks := m.hooks.Load(); hooks != nil && hooks.onEvict != nil {
+		hooks.onEvict(key, atom.load(), reason)
+	}
+}
@@ -0,0 +1,66 @@
+package buffered
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_OnSet(t *testing.T) {
+	mc := NewMulti[string, int]()
+	var (
+		mux sync.Mutex
+		set = map[string]int{}
+	)
+	mc.OnSet(func(key string, val int) {
+		mux.Lock()
+		defer mux.Unlock()
+		set[key] = val
+	})
+	mc.PreheatFrom(map[string]int{"initial": 1})
+	mc.Set("a", 1)
+	mc.SetAll(map[string]int{"b": 2, "c": 3})
+	_, err := Update(mc, "a", func(current int) int { return current + 10 })
+	require.NoError(t, err)
+	mc.SetManualFlush(true)
+	mc.Set("d", 4)
+	assert.NotContains(t, set, "d", "Staged values should not be reported until they're flushed")
+	mc.Flush()
+
+	assert.Equal(t, map[string]int{"a": 11, "b": 2, "c": 3, "d": 4}, set)
+}
+
+func TestMultiCache_OnEvict(t *testing.T) {
+	type eviction struct {
+		key    string
+		val    int
+		reason cache.Reason
+	}
+	mc := NewMulti[string, int]()
+	var (
+		mux       sync.Mutex
+		evictions []eviction
+	)
+	mc.OnEvict(func(key string, val int, reason cache.Reason) {
+		mux.Lock()
+		defer mux.Unlock()
+		evictions = append(evictions, eviction{key, val, reason})
+	})
+	mc.SetWriteTTL(20 * time.Millisecond)
+	mc.Set("a", 1)
+	mc.Set("a", 2)
+	mc.Set("b", 3)
+	mc.Unset("a")
+	mc.Unset("never-set")
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 1, mc.RemoveExpired())
+
+	assert.Equal(t, []eviction{
+		{"a", 2, cache.ReasonRemoved},
+		{"b", 3, cache.ReasonExpired},
+	}, evictions)
+}
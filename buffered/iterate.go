@@ -0,0 +1,38 @@
+package buffered
+
+// Keys returns the keys of all values currently held in the write buffer, in no particular order.
+// This includes every key that has been set and hasn't expired or been unset, whether or not it's cached in the read cache.
+// Values staged with manual flushing aren't included until they're flushed.
+func (m *MultiCache[K, V]) Keys() []K {
+	m.batch.RLock()
+	defer m.batch.RUnlock()
+	return m.writeBuffer.Keys()
+}
+
+// CachedKeys returns the keys of all values currently held in the read cache, in no particular order.
+// Along with Keys, this is useful for checking how much of the write buffer is being served by the read cache.
+func (m *MultiCache[K, V]) CachedKeys() []K {
+	return m.readCache.Keys()
+}
+
+// ForEach will call fn with each key and value currently held in the write buffer, in no particular order, until fn returns false.
+// The values are collected before fn is called, so fn may safely use the MultiCache, and every value reflects the same point in time with respect to SetAll.
+// Unlike Get, this will not load any values.
+func (m *MultiCache[K, V]) ForEach(fn func(key K, val V) bool) {
+	type pair struct {
+		key K
+		val V
+	}
+	var pairs []pair
+	m.batch.RLock()
+	m.writeBuffer.ForEach(func(key K, atom *TypedAtomic[V]) bool {
+		pairs = append(pairs, pair{key: key, val: atom.load()})
+		return true
+	})
+	m.batch.RUnlock()
+	for _, p := range pairs {
+		if !fn(p.key, p.val) {
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package buffered
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Keys(t *testing.T) {
+	mc := NewMulti[string, int]()
+	assert.Empty(t, mc.Keys())
+	mc.Set("a", 1)
+	mc.SetAll(map[string]int{"b": 2, "c": 3})
+	mc.Unset("c")
+	assert.Equal(t, 0, mc.MustGet("d"))
+	assert.ElementsMatch(t, []string{"a", "b"}, mc.Keys(), "Keys that were unset or never set should not be listed")
+
+	assert.ElementsMatch(t, []string{"d"}, mc.CachedKeys())
+	assert.Equal(t, 1, mc.MustGet("a"))
+	assert.ElementsMatch(t, []string{"a", "d"}, mc.CachedKeys())
+}
+
+func TestMultiCache_ForEach(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.SetAll(map[string]int{"a": 1, "b": 2, "c": 3})
+
+	seen := map[string]int{}
+	mc.ForEach(func(key string, val int) bool {
+		seen[key] = val
+		mc.Set(key, val*10)
+		return true
+	})
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, seen)
+	assert.Equal(t, 20, mc.MustGet("b"), "fn should be able to use the MultiCache")
+
+	var calls int
+	mc.ForEach(func(key string, val int) bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, 1, calls)
+}
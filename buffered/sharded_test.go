@@ -0,0 +1,69 @@
+package buffered
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShardedMulti(t *testing.T) {
+	mc := NewShardedMulti[int, int](4)
+	var wg sync.WaitGroup
+	wg.Add(8)
+	for w := 0; w < 8; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				mc.Set(w*100+i, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Len(t, mc.Keys(), 800)
+	assert.Equal(t, 800, mc.Stats().Buffered)
+	assert.Equal(t, 42, mc.MustGet(742))
+	assert.Equal(t, 0, mc.MustGet(1000))
+	assert.False(t, mc.Contains(1000))
+
+	val, ok := mc.Delete(742)
+	assert.True(t, ok)
+	assert.Equal(t, 42, val)
+	assert.Equal(t, 0, mc.MustGet(742))
+
+	mc.SetWriteTTL(20 * time.Millisecond)
+	mc.Set(2000, 1)
+	mc.Set(2001, 1)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 2, mc.RemoveExpired(), "New values written after SetWriteTTL should expire")
+	assert.Len(t, mc.Keys(), 799)
+
+	assert.Panics(t, func() {
+		NewShardedMulti[int, int](0)
+	})
+}
+
+func TestNewShardedMultiWithLoader(t *testing.T) {
+	errLoad := errors.New("load failed")
+	mc := NewShardedMultiWithLoader[int, int](4, func(key int) (int, error) {
+		if key < 0 {
+			return 0, errLoad
+		}
+		return key * 2, nil
+	})
+	assert.Equal(t, 4, mc.MustGet(2))
+	assert.True(t, mc.Contains(2))
+	_, err := mc.Get(-1)
+	assert.ErrorIs(t, err, errLoad)
+
+	updated, err := Update(mc, 3, func(current int) int { return current + 1 })
+	require.NoError(t, err)
+	assert.Equal(t, 7, updated)
+
+	assert.Panics(t, func() {
+		NewShardedMultiWithLoader[int, int](4, nil)
+	})
+}
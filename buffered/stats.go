@@ -0,0 +1,34 @@
+package buffered
+
+import (
+	"sync/atomic"
+
+	"github.com/saylorsolutions/cache"
+)
+
+// Stats is a snapshot of a MultiCache's statistics, which may be used to measure the tradeoff of memory for throughput.
+// The embedded [cache.Stats] describes the read cache, so Hits and Misses count reads that were served by the read cache, and reads that passed through to the write buffer.
+type Stats struct {
+	cache.Stats
+	// Writes is the number of values written to the write buffer with Set, SetAll, Flush, CompareAndSwap, or Update.
+	Writes uint64
+	// Invalidations is the number of keys invalidated in the read cache, including invalidations caused by writes.
+	Invalidations uint64
+	// Buffered is the number of valid values held in the write buffer when the snapshot was taken.
+	Buffered int
+}
+
+type stats struct {
+	writes        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// Stats returns a snapshot of the MultiCache's statistics.
+func (m *MultiCache[K, V]) Stats() Stats {
+	return Stats{
+		Stats:         m.readCache.Stats(),
+		Writes:        m.stats.writes.Load(),
+		Invalidations: m.stats.invalidations.Load(),
+		Buffered:      m.writeBuffer.Len(),
+	}
+}
@@ -0,0 +1,32 @@
+package buffered
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_Stats(t *testing.T) {
+	c := NewMulti[string, int]()
+	c.Set("a", 1)
+	c.SetAll(map[string]int{"b": 2, "c": 3})
+	swapped, err := CompareAndSwap(c, "a", 1, 2)
+	require.NoError(t, err)
+	assert.True(t, swapped)
+
+	assert.Equal(t, 2, c.MustGet("a"))
+	assert.Equal(t, 2, c.MustGet("a"))
+	assert.Equal(t, 2, c.MustGet("b"))
+	c.Invalidate("b")
+	c.Unset("c")
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.InDelta(t, 1.0/3, stats.HitRatio(), 0.001)
+	assert.Equal(t, uint64(4), stats.Writes)
+	assert.Equal(t, uint64(6), stats.Invalidations)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, 2, stats.Buffered)
+}
@@ -0,0 +1,37 @@
+package buffered
+
+import (
+	"time"
+)
+
+// SetWriteTTL sets the time to live of values in the write buffer, starting from the last time each was written with Set, SetAll, CompareAndSwap, or Update.
+// By default, values in the write buffer never expire, so keys that are set once and then abandoned will be held for the life of the MultiCache.
+// Like SetTTLPolicy, this must be called before keys are first written or read, since keys already held by the write buffer aren't affected.
+// Once a value expires, its key will read as if it had never been set, or will be loaded again by a MultiCache created with NewMultiWithLoader.
+//
+// Expired values are removed from the write buffer, and invalidated in the read cache, by RemoveExpired, which should be called periodically in long-running processes.
+// A value that expires before it's flushed by write-behind will not be flushed.
+//
+// This method will panic if ttl <= 0.
+func (m *MultiCache[K, V]) SetWriteTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		panic("ttl <= 0")
+	}
+	m.writeBuffer.SetTTLPolicy(ttl)
+	m.writeTTL.Store(true)
+}
+
+// RemoveExpired removes expired values from both the write buffer and the read cache, and returns the number of values removed from the write buffer.
+// Keys removed from the write buffer are also invalidated in the read cache, so readers won't observe a value after it expires.
+func (m *MultiCache[K, V]) RemoveExpired() int {
+	removed := m.writeBuffer.RemoveExpired()
+	m.readCache.RemoveExpired()
+	return removed
+}
+
+// touch restarts the write buffer's time to live for key after atom was written in place.
+func (m *MultiCache[K, V]) touch(key K, atom *TypedAtomic[V]) {
+	if m.writeTTL.Load() {
+		m.writeBuffer.Set(key, atom)
+	}
+}
@@ -0,0 +1,33 @@
+package buffered
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetWriteTTL(t *testing.T) {
+	c := NewMulti[string, int]()
+	c.SetWriteTTL(50 * time.Millisecond)
+	c.Set("abandoned", 1)
+	c.Set("active", 2)
+	assert.Equal(t, 1, c.MustGet("abandoned"))
+
+	time.Sleep(30 * time.Millisecond)
+	c.Set("active", 3)
+	_, err := Update(c, "active", func(current int) int { return current + 1 })
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+
+	assert.False(t, c.Contains("abandoned"), "The abandoned value should have expired")
+	assert.True(t, c.Contains("active"), "Writing a value should restart its time to live")
+	assert.Equal(t, 1, c.RemoveExpired())
+	assert.Equal(t, 0, c.MustGet("abandoned"), "The expired value should be invalidated in the read cache")
+	assert.Equal(t, 4, c.MustGet("active"))
+
+	assert.Panics(t, func() {
+		c.SetWriteTTL(0)
+	})
+}
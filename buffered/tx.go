@@ -0,0 +1,77 @@
+package buffered
+
+import "errors"
+
+// ErrTxDone is returned when a Tx is used after it has been committed or rolled back.
+var ErrTxDone = errors.New("transaction already committed or rolled back")
+
+// Tx stages updates to several keys of a MultiCache, so they can be published together with Commit or discarded with Rollback.
+// This is useful for maintaining invariants across related keys, since readers will observe either all or none of a Tx's updates.
+//
+// A Tx is not safe for concurrent use.
+type Tx[K comparable, V any] struct {
+	m    *MultiCache[K, V]
+	vals map[K]V
+	done bool
+}
+
+// Begin starts a new Tx for the MultiCache.
+// Nothing set in the Tx is visible to other readers until it's committed.
+func (m *MultiCache[K, V]) Begin() *Tx[K, V] {
+	return &Tx[K, V]{
+		m:    m,
+		vals: map[K]V{},
+	}
+}
+
+// Transact runs fn with a new Tx, and commits it if fn returns nil.
+// If fn returns an error or panics, then the Tx is rolled back and nothing set in it is published.
+func (m *MultiCache[K, V]) Transact(fn func(tx *Tx[K, V]) error) error {
+	tx := m.Begin()
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Set stages val for key, replacing any value previously staged for key in the Tx.
+func (tx *Tx[K, V]) Set(key K, val V) error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.vals[key] = val
+	return nil
+}
+
+// Get returns the value staged for key in the Tx, or the value from the MultiCache if nothing has been staged.
+func (tx *Tx[K, V]) Get(key K) (V, error) {
+	if tx.done {
+		var mt V
+		return mt, ErrTxDone
+	}
+	if val, ok := tx.vals[key]; ok {
+		return val, nil
+	}
+	return tx.m.Get(key)
+}
+
+// Commit publishes every value staged in the Tx at once, as if they were set with [MultiCache.SetAll].
+func (tx *Tx[K, V]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	if len(tx.vals) > 0 {
+		tx.m.SetAll(tx.vals)
+	}
+	tx.vals = nil
+	return nil
+}
+
+// Rollback discards every value staged in the Tx.
+// Calling Rollback after the Tx has been committed or rolled back has no effect, so it's safe to defer.
+func (tx *Tx[K, V]) Rollback() {
+	tx.done = true
+	tx.vals = nil
+}
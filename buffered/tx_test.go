@@ -0,0 +1,77 @@
+package buffered
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTx(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.SetAll(map[string]int{"checking": 100, "savings": 0})
+
+	tx := mc.Begin()
+	require.NoError(t, tx.Set("checking", 60))
+	require.NoError(t, tx.Set("savings", 40))
+	val, err := tx.Get("savings")
+	require.NoError(t, err)
+	assert.Equal(t, 40, val, "The Tx should observe its own writes")
+	assert.Equal(t, 0, mc.MustGet("savings"), "Staged writes should not be visible before Commit")
+
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 60, mc.MustGet("checking"))
+	assert.Equal(t, 40, mc.MustGet("savings"))
+
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+	assert.ErrorIs(t, tx.Set("checking", 0), ErrTxDone)
+	_, err = tx.Get("checking")
+	assert.ErrorIs(t, err, ErrTxDone)
+
+	tx = mc.Begin()
+	require.NoError(t, tx.Set("checking", 0))
+	tx.Rollback()
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+	assert.Equal(t, 60, mc.MustGet("checking"), "Rolled back writes should be discarded")
+}
+
+func TestMultiCache_Transact(t *testing.T) {
+	mc := NewMulti[string, int]()
+	mc.SetAll(map[string]int{"checking": 100, "savings": 0})
+	transfer := func(amount int) func(tx *Tx[string, int]) error {
+		return func(tx *Tx[string, int]) error {
+			checking, err := tx.Get("checking")
+			if err != nil {
+				return err
+			}
+			if err := tx.Set("checking", checking-amount); err != nil {
+				return err
+			}
+			if checking < amount {
+				return errors.New("insufficient funds")
+			}
+			savings, err := tx.Get("savings")
+			if err != nil {
+				return err
+			}
+			return tx.Set("savings", savings+amount)
+		}
+	}
+
+	require.NoError(t, mc.Transact(transfer(70)))
+	assert.Equal(t, 30, mc.MustGet("checking"))
+	assert.Equal(t, 70, mc.MustGet("savings"))
+
+	assert.EqualError(t, mc.Transact(transfer(50)), "insufficient funds")
+	assert.Equal(t, 30, mc.MustGet("checking"), "A failed transaction should be rolled back")
+	assert.Equal(t, 70, mc.MustGet("savings"))
+
+	assert.Panics(t, func() {
+		_ = mc.Transact(func(tx *Tx[string, int]) error {
+			_ = tx.Set("checking", 0)
+			panic("failed")
+		})
+	})
+	assert.Equal(t, 30, mc.MustGet("checking"), "A panicking transaction should be rolled back")
+}
@@ -0,0 +1,52 @@
+package buffered
+
+import (
+	"github.com/saylorsolutions/cache"
+)
+
+// Value applies the double-buffered design of [MultiCache] to a single value, such as a configuration object that's read by many goroutines and updated by one.
+// Reads use a read cache, which passes through to the write buffer when it has been invalidated.
+// Setting a Value will invalidate the read cache.
+type Value[T any] struct {
+	readCache   *cache.Value[stamped[T]]
+	writeBuffer *TypedAtomic[T]
+}
+
+// NewValue creates a new Value, which will return initial until it's set.
+func NewValue[T any](initial T) *Value[T] {
+	buffer := new(TypedAtomic[T])
+	buffer.Store(initial)
+	return &Value[T]{
+		readCache: cache.New[stamped[T]](func() (stamped[T], error) {
+			val, version := buffer.stamp()
+			return stamped[T]{val: val, atom: buffer, version: version}, nil
+		}),
+		writeBuffer: buffer,
+	}
+}
+
+// Get returns the current value.
+// Like [MultiCache.Get], the read cache is checked against the version of the write buffer, so a value replaced before Get was called will never be returned.
+func (v *Value[T]) Get() T {
+	for {
+		// The loader can't fail, so neither can Get.
+		s := v.readCache.MustGet()
+		if s.atom.isCurrent(s.version) {
+			return s.val
+		}
+		v.readCache.Invalidate()
+	}
+}
+
+// Set will set the value in the write buffer.
+// This implicitly invalidates the read cache, so the next call to Get will return val.
+func (v *Value[T]) Set(val T) {
+	v.writeBuffer.Store(val)
+	v.readCache.Invalidate()
+}
+
+// SetEventHandler sets a handler that will receive a [cache.Event] for each hit, miss, load, and invalidation of the read cache.
+// See [cache.Value.SetEventHandler] for details.
+func (v *Value[T]) SetEventHandler(source string, handler cache.EventHandler) {
+	v.readCache.SetEventHandler(source, handler)
+}
@@ -0,0 +1,48 @@
+package buffered
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type config struct {
+	Name    string
+	Retries int
+}
+
+func TestValue(t *testing.T) {
+	v := NewValue(config{Name: "initial"})
+	assert.Equal(t, config{Name: "initial"}, v.Get())
+
+	v.Set(config{Name: "updated", Retries: 3})
+	assert.Equal(t, config{Name: "updated", Retries: 3}, v.Get())
+}
+
+func TestValue_ConcurrentReaders(t *testing.T) {
+	v := NewValue(0)
+	var (
+		wg   sync.WaitGroup
+		seen atomic.Int32
+	)
+	wg.Add(8)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer wg.Done()
+			deadline := time.Now().Add(3 * time.Second)
+			for time.Now().Before(deadline) {
+				if v.Get() == 1 {
+					seen.Add(1)
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	v.Set(1)
+	wg.Wait()
+	assert.Equal(t, int32(8), seen.Load(), "Every reader should observe the new value")
+}
@@ -0,0 +1,198 @@
+package buffered
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FlushFunc persists a value written to a [MultiCache] to a backing store.
+type FlushFunc[K comparable, V any] func(key K, val V) error
+
+// writeBehind tracks values that have been set, but not yet persisted with a FlushFunc.
+type writeBehind[K comparable, V any] struct {
+	flush     FlushFunc[K, V]
+	threshold int
+	// trigger requests a flush before the next interval, once threshold keys are pending.
+	trigger chan struct{}
+	// flushing serializes flushes, so a key is never persisted by two flushes at once.
+	flushing sync.Mutex
+	// stop is closed by Close to end background flushing.
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mux     sync.Mutex
+	pending map[K]struct{}
+	onError func(key K, err error)
+}
+
+// SetWriteBehind makes the MultiCache a write-behind cache, where values written with Set are persisted to a backing store with flush in the background.
+// Pending writes are flushed every interval, or sooner once threshold keys are pending, and only the latest value for a key is flushed.
+// Writes may also be flushed immediately with Persist.
+// If threshold is 0, then writes are only flushed every interval.
+//
+// If flushing a key fails, then it will be retried with the next flush, unless it's set again or removed with Unset first.
+// Errors from background flushes may be observed with OnFlushError.
+// Once ctx is done, pending writes are flushed one last time, and background flushing stops.
+//
+// This method will panic if flush is nil, interval <= 0, threshold < 0, or if it's called more than once.
+func (m *MultiCache[K, V]) SetWriteBehind(ctx context.Context, flush FlushFunc[K, V], interval time.Duration, threshold int) {
+	if flush == nil {
+		panic("nil flush func")
+	}
+	if interval <= 0 {
+		panic("interval <= 0")
+	}
+	if threshold < 0 {
+		panic("threshold < 0")
+	}
+	wb := &writeBehind[K, V]{
+		flush:     flush,
+		threshold: threshold,
+		trigger:   make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		pending:   map[K]struct{}{},
+	}
+	if !m.writeBehind.CompareAndSwap(nil, wb) {
+		panic("write behind already set")
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				m.persist(context.Background(), wb, wb.reportError)
+				return
+			case <-wb.stop:
+				return
+			case <-ticker.C:
+			case <-wb.trigger:
+			}
+			m.persist(context.Background(), wb, wb.reportError)
+		}
+	}()
+}
+
+// OnFlushError sets a function that will be called with each error returned from the FlushFunc set with SetWriteBehind during a background flush.
+// Errors from Persist are returned to its caller instead.
+func (m *MultiCache[K, V]) OnFlushError(fn func(key K, err error)) {
+	wb := m.writeBehind.Load()
+	if wb == nil {
+		return
+	}
+	wb.mux.Lock()
+	defer wb.mux.Unlock()
+	wb.onError = fn
+}
+
+// Persist immediately flushes every pending write with the FlushFunc set with SetWriteBehind.
+// If any keys fail to flush, then an error joining every failure will be returned, and those keys will be retried with the next flush.
+// If write-behind isn't enabled, then this does nothing.
+func (m *MultiCache[K, V]) Persist() error {
+	wb := m.writeBehind.Load()
+	if wb == nil {
+		return nil
+	}
+	var errs []error
+	m.persist(context.Background(), wb, func(key K, err error) {
+		errs = append(errs, fmt.Errorf("error flushing key '%v': %w", key, err))
+	})
+	return errors.Join(errs...)
+}
+
+// Close stops background flushing, and flushes every pending write with the FlushFunc set with SetWriteBehind, for a graceful shutdown.
+// If ctx is done before every pending write is flushed, then the remaining writes are left pending, and ctx's error is returned along with any flush failures.
+// Close will wait for a flush that's already in progress to complete.
+//
+// Values may still be set and read after Close, but they will only be flushed with Persist.
+// If write-behind isn't enabled, then this does nothing.
+func (m *MultiCache[K, V]) Close(ctx context.Context) error {
+	wb := m.writeBehind.Load()
+	if wb == nil {
+		return nil
+	}
+	wb.stopOnce.Do(func() {
+		close(wb.stop)
+	})
+	var errs []error
+	m.persist(ctx, wb, func(key K, err error) {
+		errs = append(errs, fmt.Errorf("error flushing key '%v': %w", key, err))
+	})
+	if err := ctx.Err(); err != nil && wb.hasPending() {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// persist flushes every pending write in wb, and calls report with each failure.
+// Once ctx is done, any writes that haven't been flushed are left pending.
+func (m *MultiCache[K, V]) persist(ctx context.Context, wb *writeBehind[K, V], report func(key K, err error)) {
+	wb.flushing.Lock()
+	defer wb.flushing.Unlock()
+	wb.mux.Lock()
+	pending := wb.pending
+	wb.pending = map[K]struct{}{}
+	wb.mux.Unlock()
+	for key := range pending {
+		if ctx.Err() != nil {
+			wb.retry(key)
+			continue
+		}
+		atom, ok := m.writeBuffer.GetIfPresent(key)
+		if !ok {
+			// Removed with Unset since it was set.
+			continue
+		}
+		if err := wb.flush(key, atom.load()); err != nil {
+			wb.retry(key)
+			report(key, err)
+		}
+	}
+}
+
+// markPending records that key has been set, and requests an early flush if the threshold has been reached.
+func (wb *writeBehind[K, V]) markPending(key K) {
+	wb.mux.Lock()
+	wb.pending[key] = struct{}{}
+	full := wb.threshold > 0 && len(wb.pending) >= wb.threshold
+	wb.mux.Unlock()
+	if full {
+		select {
+		case wb.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// retry marks key as pending again after a failed flush.
+func (wb *writeBehind[K, V]) retry(key K) {
+	wb.mux.Lock()
+	defer wb.mux.Unlock()
+	wb.pending[key] = struct{}{}
+}
+
+// hasPending returns true if any writes are waiting to be flushed.
+func (wb *writeBehind[K, V]) hasPending() bool {
+	wb.mux.Lock()
+	defer wb.mux.Unlock()
+	return len(wb.pending) > 0
+}
+
+// forget drops a pending write for key, since it was removed with Unset.
+func (wb *writeBehind[K, V]) forget(key K) {
+	wb.mux.Lock()
+	defer wb.mux.Unlock()
+	delete(wb.pending, key)
+}
+
+func (wb *writeBehind[K, V]) reportError(key K, err error) {
+	wb.mux.Lock()
+	onError := wb.onError
+	wb.mux.Unlock()
+	if onError != nil {
+		onError(key, err)
+	}
+}
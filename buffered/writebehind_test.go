@@ -0,0 +1,134 @@
+package buffered
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// store is a backing store for testing write-behind.
+type store struct {
+	mux     sync.Mutex
+	vals    map[int]int
+	flushes int
+	fail    bool
+}
+
+func (s *store) flush(key int, val int) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.fail {
+		return errors.New("store unavailable")
+	}
+	s.flushes++
+	s.vals[key] = val
+	return nil
+}
+
+func (s *store) snapshot() (map[int]int, int) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	vals := map[int]int{}
+	for k, v := range s.vals {
+		vals[k] = v
+	}
+	return vals, s.flushes
+}
+
+func TestMultiCache_SetWriteBehind(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &store{vals: map[int]int{}}
+	mc := NewMulti[int, int]()
+	mc.SetWriteBehind(ctx, s.flush, 20*time.Millisecond, 0)
+
+	mc.Set(1, 1)
+	mc.Set(1, 2)
+	mc.Set(2, 2)
+	assert.Eventually(t, func() bool {
+		vals, _ := s.snapshot()
+		return len(vals) == 2
+	}, time.Second, 5*time.Millisecond)
+	vals, flushes := s.snapshot()
+	assert.Equal(t, map[int]int{1: 2, 2: 2}, vals)
+	assert.Equal(t, 2, flushes, "Only the latest value for a key should be flushed")
+
+	mc.Set(3, 3)
+	mc.Unset(3)
+	assert.NoError(t, mc.Persist())
+	vals, _ = s.snapshot()
+	assert.NotContains(t, vals, 3, "Unset values should not be flushed")
+
+	assert.Panics(t, func() {
+		mc.SetWriteBehind(ctx, s.flush, time.Second, 0)
+	})
+}
+
+func TestMultiCache_SetWriteBehind_Threshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &store{vals: map[int]int{}}
+	mc := NewMulti[int, int]()
+	mc.SetWriteBehind(ctx, s.flush, time.Hour, 3)
+
+	mc.Set(1, 1)
+	mc.Set(2, 2)
+	time.Sleep(20 * time.Millisecond)
+	vals, _ := s.snapshot()
+	assert.Empty(t, vals)
+	mc.Set(3, 3)
+	assert.Eventually(t, func() bool {
+		vals, _ := s.snapshot()
+		return len(vals) == 3
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestMultiCache_SetWriteBehind_Errors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &store{vals: map[int]int{}, fail: true}
+	mc := NewMulti[int, int]()
+	mc.SetWriteBehind(ctx, s.flush, time.Hour, 0)
+	failed := make(chan int, 1)
+	mc.OnFlushError(func(key int, err error) {
+		failed <- key
+	})
+
+	mc.Set(1, 1)
+	assert.Error(t, mc.Persist())
+
+	s.mux.Lock()
+	s.fail = false
+	s.mux.Unlock()
+	assert.NoError(t, mc.Persist(), "Failed keys should be retried")
+	vals, _ := s.snapshot()
+	assert.Equal(t, map[int]int{1: 1}, vals)
+
+	s.mux.Lock()
+	s.fail = true
+	s.mux.Unlock()
+	mc.Set(2, 2)
+	cancel()
+	select {
+	case key := <-failed:
+		assert.Equal(t, 2, key, "Pending writes should be flushed when ctx is done")
+	case <-time.After(time.Second):
+		t.Error("Expected a flush error")
+	}
+}
+
+func TestMultiCache_SetWriteBehind_Panics(t *testing.T) {
+	mc := NewMulti[int, int]()
+	assert.Panics(t, func() {
+		mc.SetWriteBehind(context.Background(), nil, time.Second, 0)
+	})
+	assert.Panics(t, func() {
+		mc.SetWriteBehind(context.Background(), func(int, int) error { return nil }, 0, 0)
+	})
+	assert.Panics(t, func() {
+		mc.SetWriteBehind(context.Background(), func(int, int) error { return nil }, time.Second, -1)
+	})
+}
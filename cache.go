@@ -1,8 +1,12 @@
 package cache
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,11 +26,48 @@ type Value[T any] struct {
 	val      *T
 	loadFunc LoaderFunc[T]
 
-	mux          sync.RWMutex
+	mux sync.RWMutex
+	// loads counts completed loads, so callers that waited on a load can tell that it happened.
+	loads        atomic.Uint64
+	loadErr      error
 	ttl          time.Duration
 	expiration   time.Time
 	getRefreshes bool
+	// ttlJitter is the fraction of ttl that each loaded value's lifetime may randomly vary by.
+	ttlJitter    float64
 	onInvalidate OnInvalidateFunc
+	events       atomic.Pointer[eventSink]
+	// stats is only updated by Get, so loads made by a MultiCache are counted by the MultiCache instead.
+	stats statsCounter
+	// onLoad is called with each successfully loaded value while the write lock is held.
+	// If the new value replaces a previously cached value, then prev will point to it.
+	onLoad func(prev *T, val T)
+	// valid is published each time the cached value or its expiration changes, so TryGet can read it without the lock.
+	// It's nil when there's no cached value.
+	valid atomic.Pointer[published[T]]
+}
+
+// published is a cached value as it was last stored, along with when it expires.
+type published[T any] struct {
+	val T
+	// expiration is only used if expires is true.
+	expiration   time.Time
+	expires      bool
+	getRefreshes bool
+}
+
+// publishLocked must be called while holding the write lock, after the cached value, its expiration, or its time to live change.
+func (c *Value[T]) publishLocked() {
+	if c.val == nil {
+		c.valid.Store(nil)
+		return
+	}
+	c.valid.Store(&published[T]{
+		val:          *c.val,
+		expiration:   c.expiration,
+		expires:      c.ttl > 0,
+		getRefreshes: c.getRefreshes,
+	})
 }
 
 func (c *Value[T]) cacheExpired() bool {
@@ -60,7 +101,60 @@ func NewEager[T any](loader LoaderFunc[T]) (*Value[T], error) {
 
 // Get will return the cached value, if it exists, or call the LoaderFunc otherwise.
 // Any error returned while loading the cache will be returned.
+//
+// The LoaderFunc is only called by one goroutine at a time.
+// Goroutines that call Get while a load is in progress will wait for it to finish and share its result, including any error, rather than calling the LoaderFunc again.
 func (c *Value[T]) Get() (T, error) {
+	start := time.Now()
+	val, outcome, err := c.getWith(c.loadFunc)
+	switch {
+	case outcome == outcomeCached || (outcome == outcomeShared && err == nil):
+		c.stats.hits.Add(1)
+	case outcome == outcomeLoaded:
+		c.stats.misses.Add(1)
+		c.stats.recordLoads(1, err)
+	default:
+		c.stats.misses.Add(1)
+	}
+	if events := c.events.Load(); events != nil {
+		c.emitGet(events, outcome, err, time.Since(start))
+	}
+	return val, err
+}
+
+// emitGet emits the events for a call to Get with the given outcome, which took the given duration.
+func (c *Value[T]) emitGet(events *eventSink, outcome loadOutcome, err error, duration time.Duration) {
+	switch {
+	case outcome == outcomeCached || (outcome == outcomeShared && err == nil):
+		events.emit(Event{Type: EventHit})
+	case outcome == outcomeShared:
+		events.emit(Event{Type: EventMiss})
+	case err != nil:
+		events.emit(Event{Type: EventMiss})
+		events.emit(Event{Type: EventLoadError, Err: err, Duration: duration})
+	default:
+		events.emit(Event{Type: EventMiss})
+		events.emit(Event{Type: EventLoad, Duration: duration})
+	}
+}
+
+// loadOutcome describes how a value was retrieved by getWith.
+type loadOutcome int
+
+const (
+	// outcomeCached means that a valid value was already cached.
+	outcomeCached loadOutcome = iota
+	// outcomeLoaded means that the loader was called by this caller.
+	outcomeLoaded
+	// outcomeShared means that the result of a load started by another caller was shared with this caller.
+	outcomeShared
+)
+
+// getWith is the same as Get, except that loader is used in place of the Value's LoaderFunc if a load is needed.
+// The returned loadOutcome reports whether the value was cached, loaded by this caller, or shared from a concurrent load.
+func (c *Value[T]) getWith(loader LoaderFunc[T]) (T, loadOutcome, error) {
+	// This must be read before waiting on the lock, since a load may be holding it.
+	seq := c.loads.Load()
 	c.mux.RLock()
 	if c.val != nil && !c.cacheExpired() {
 		val := *c.val
@@ -69,10 +163,10 @@ func (c *Value[T]) Get() (T, error) {
 		if getRefreshes {
 			c.refreshTimer()
 		}
-		return val, nil
+		return val, outcomeCached, nil
 	}
 	c.mux.RUnlock()
-	return c.load()
+	return c.load(loader, seq)
 }
 
 // MustGet does the same thing as Get, but it will panic if an error occurs.
@@ -84,6 +178,28 @@ func (c *Value[T]) MustGet() T {
 	return val
 }
 
+// TryGet will return the cached value and true if it's present and still valid.
+// Unlike Get, this method will never call the LoaderFunc, and it doesn't take the Value's lock, so it returns the last valid value even while another goroutine is loading or modifying the Value.
+// If there's no valid cached value, the zero value of T and false will be returned.
+//
+// This is useful for hot paths where a cache miss is preferred over waiting for a load.
+func (c *Value[T]) TryGet() (T, bool) {
+	p := c.valid.Load()
+	if p == nil || (p.expires && p.expiration.Before(time.Now())) {
+		var mt T
+		return mt, false
+	}
+	if p.getRefreshes && c.mux.TryLock() {
+		// The refresh is skipped rather than waiting on the lock.
+		if c.val != nil && c.ttl > 0 {
+			c.expiration = time.Now().Add(c.ttl)
+			c.publishLocked()
+		}
+		c.mux.Unlock()
+	}
+	return p.val, true
+}
+
 func (c *Value[T]) refreshTimer() {
 	c.mux.RLock()
 	ttl := c.ttl
@@ -94,36 +210,198 @@ func (c *Value[T]) refreshTimer() {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 	c.expiration = time.Now().Add(ttl)
+	c.publishLocked()
 }
 
-func (c *Value[T]) load() (T, error) {
+// load calls loader while holding the write lock, unless another load has completed since seq was observed.
+// In that case, the other load's result is shared rather than calling loader again.
+func (c *Value[T]) load(loader LoaderFunc[T], seq uint64) (T, loadOutcome, error) {
 	c.mux.Lock()
 	defer c.mux.Unlock()
+	var mt T
 	if c.val != nil && !c.cacheExpired() {
-		return *c.val, nil
+		// Another goroutine loaded the value while waiting for the lock.
+		return *c.val, outcomeShared, nil
 	}
-	if c.loadFunc == nil {
+	if c.loads.Load() != seq && c.loadErr != nil && !callerSpecific(c.loadErr) {
+		// Another goroutine's load failed while waiting for the lock.
+		return mt, outcomeShared, c.loadErr
+	}
+	if loader == nil {
 		panic("nil load func")
 	}
 
-	var mt T
-	val, err := c.loadFunc()
+	val, err := loader()
+	c.loadErr = err
+	c.loads.Add(1)
 	if err != nil {
-		return mt, err
+		return mt, outcomeLoaded, err
+	}
+	c.setLocked(val)
+	return val, outcomeLoaded, nil
+}
+
+// callerSpecific returns true if err was caused by the context of the caller that loaded it, such as a cancelled GetCtx.
+// These errors aren't shared with other callers waiting on the same load, since their contexts may still be live, so they load the value themselves.
+func callerSpecific(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// refresh calls loader without holding the lock, so the current value may still be read while a new one is loaded.
+// If the load succeeds, then the new value is cached as if it were loaded normally.
+// If loader returns a time to live > 0, then it replaces the Value's time to live.
+func (c *Value[T]) refresh(loader LoaderTTLFunc[T]) error {
+	val, ttl, err := loader()
+	if err != nil {
+		return err
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if ttl > 0 {
+		c.ttl = ttl
 	}
+	c.setLocked(val)
+	return nil
+}
+
+// store sets the cached value as if it had been loaded by the LoaderFunc.
+func (c *Value[T]) store(val T) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.setLocked(val)
+}
+
+// getOrStore returns the cached value and true if it's valid, or stores val as if it had been loaded and returns false.
+func (c *Value[T]) getOrStore(val T) (T, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.val != nil && !c.cacheExpired() {
+		return *c.val, true
+	}
+	c.setLocked(val)
+	return val, false
+}
+
+// setLocked must be called while holding the write lock.
+func (c *Value[T]) setLocked(val T) {
+	prev := c.val
 	c.val = &val
 	if c.ttl > 0 {
-		c.expiration = time.Now().Add(c.ttl)
+		c.expiration = time.Now().Add(jitter(c.ttl, c.ttlJitter))
+	}
+	if c.onLoad != nil {
+		c.onLoad(prev, val)
+	}
+	c.publishLocked()
+}
+
+// expiresAt returns the time that the currently cached value will expire, or false if there's no cached value or no time to live.
+func (c *Value[T]) expiresAt() (time.Time, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.val == nil || c.ttl <= 0 {
+		return time.Time{}, false
+	}
+	return c.expiration, true
+}
+
+// tryExpiresAt is like expiresAt, but acquired will be false if the Value is currently locked by another goroutine, such as during a load.
+func (c *Value[T]) tryExpiresAt() (at time.Time, ok bool, acquired bool) {
+	if !c.mux.TryRLock() {
+		return time.Time{}, false, false
+	}
+	defer c.mux.RUnlock()
+	if c.val == nil || c.ttl <= 0 {
+		return time.Time{}, false, true
+	}
+	return c.expiration, true, true
+}
+
+// current returns the currently cached value, even if it's expired.
+func (c *Value[T]) current() (T, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.val == nil {
+		var mt T
+		return mt, false
+	}
+	return *c.val, true
+}
+
+// cached returns the currently cached value if it's valid, without loading or refreshing it.
+func (c *Value[T]) cached() (T, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.val == nil || c.cacheExpired() {
+		var mt T
+		return mt, false
+	}
+	return *c.val, true
+}
+
+// tryCached is like cached, but will return false rather than waiting if the Value is currently locked by another goroutine, such as during a load.
+func (c *Value[T]) tryCached() (T, bool) {
+	var mt T
+	if !c.mux.TryRLock() {
+		return mt, false
+	}
+	defer c.mux.RUnlock()
+	if c.val == nil || c.cacheExpired() {
+		return mt, false
+	}
+	return *c.val, true
+}
+
+// stale returns the currently cached value if it has expired, but expired less than maxStale ago.
+func (c *Value[T]) stale(maxStale time.Duration) (T, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.val == nil || !c.cacheExpired() || !time.Now().Before(c.expiration.Add(maxStale)) {
+		var mt T
+		return mt, false
+	}
+	return *c.val, true
+}
+
+// cachedUntil is like cached, but also returns the time that the value will expire, which is zero if there's no time to live.
+func (c *Value[T]) cachedUntil() (T, time.Time, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	if c.val == nil || c.cacheExpired() {
+		var mt T
+		return mt, time.Time{}, false
+	}
+	if c.ttl <= 0 {
+		return *c.val, time.Time{}, true
+	}
+	return *c.val, c.expiration, true
+}
+
+// expireBy moves the expiration of the cached value earlier to at, if the Value has a time to live and would otherwise expire later.
+// The returned bool will be true if the expiration was changed.
+func (c *Value[T]) expireBy(at time.Time) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.val == nil || c.ttl <= 0 || !at.Before(c.expiration) {
+		return false
 	}
-	return val, nil
+	c.expiration = at
+	c.publishLocked()
+	return true
 }
 
 // Invalidate will remove the cached value and force a reload the next time Get is called.
 func (c *Value[T]) Invalidate() {
+	c.invalidate()
+	c.events.Load().emit(Event{Type: EventInvalidate, Reason: ReasonInvalidated})
+}
+
+func (c *Value[T]) invalidate() {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 	c.expiration = time.Time{}
 	c.val = nil
+	c.publishLocked()
 	if c.onInvalidate != nil {
 		c.onInvalidate()
 	}
@@ -158,6 +436,7 @@ func (c *Value[T]) SetTTL(ttl time.Duration) {
 	c.ttl = ttl
 	c.expiration = time.Now().Add(ttl)
 	c.getRefreshes = false
+	c.publishLocked()
 }
 
 // RemoveTTL will remove the time to live constraint on this cached Value.
@@ -167,6 +446,7 @@ func (c *Value[T]) RemoveTTL() {
 	defer c.mux.Unlock()
 	c.ttl = 0
 	c.expiration = time.Time{}
+	c.publishLocked()
 }
 
 // EnableGetTTLRefresh will change the Get behavior to refresh validity of a cached Value when it's called.
@@ -177,6 +457,15 @@ func (c *Value[T]) EnableGetTTLRefresh() {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 	c.getRefreshes = true
+	c.publishLocked()
+}
+
+// jitter returns ttl randomly adjusted by up to ±fraction of its length.
+func jitter(ttl time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return ttl
+	}
+	return ttl + time.Duration((rand.Float64()*2-1)*fraction*float64(ttl))
 }
 
 // LoaderTTLFunc is a function that returns both a value and the time that the value should be valid.
@@ -190,7 +479,13 @@ func NewWithTTL[T any](loader LoaderTTLFunc[T]) *Value[T] {
 		panic("nil loader")
 	}
 	c := new(Value[T])
-	c.loadFunc = func() (T, error) {
+	c.loadFunc = ttlLoaderFunc(c, loader)
+	return c
+}
+
+// ttlLoaderFunc adapts a LoaderTTLFunc into a LoaderFunc that sets the time to live of c.
+func ttlLoaderFunc[T any](c *Value[T], loader LoaderTTLFunc[T]) LoaderFunc[T] {
+	return func() (T, error) {
 		// This will run in a locked context, so it's fine to set the ttl.
 		val, ttl, err := loader()
 		var mt T
@@ -198,10 +493,9 @@ func NewWithTTL[T any](loader LoaderTTLFunc[T]) *Value[T] {
 			return mt, err
 		}
 		if ttl <= 0 {
-			return mt, errors.New("ttl <= 0")
+			return mt, fmt.Errorf("%w: ttl <= 0", ErrMisconfigured)
 		}
 		c.ttl = ttl
 		return val, nil
 	}
-	return c
 }
@@ -1,7 +1,10 @@
 package cache
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -228,3 +231,82 @@ func TestValue_RemoveTTL(t *testing.T) {
 	assert.Equal(t, "string", s)
 	assert.Equal(t, 2, timesCalled)
 }
+
+func TestValue_TryGet(t *testing.T) {
+	var timesCalled int
+
+	cache := New(func() (string, error) {
+		timesCalled++
+		return "string", nil
+	})
+	s, ok := cache.TryGet()
+	assert.False(t, ok, "Nothing should be cached yet")
+	assert.Equal(t, "", s)
+	assert.Equal(t, 0, timesCalled, "TryGet should never call the loader")
+
+	_, err := cache.Get()
+	assert.NoError(t, err)
+	s, ok = cache.TryGet()
+	assert.True(t, ok)
+	assert.Equal(t, "string", s)
+	assert.Equal(t, 1, timesCalled)
+
+	cache.mux.Lock()
+	s, ok = cache.TryGet()
+	stats := cache.Stats()
+	cache.mux.Unlock()
+	assert.True(t, ok, "TryGet should return the last valid value while the lock is held")
+	assert.Equal(t, "string", s)
+	assert.Equal(t, 1, stats.Entries, "Stats should not depend on whether the lock is held")
+
+	cache.Invalidate()
+	_, ok = cache.TryGet()
+	assert.False(t, ok, "Invalidated value should not be returned")
+	assert.Equal(t, 1, timesCalled)
+
+	_, err = cache.Get()
+	assert.NoError(t, err)
+	cache.SetTTL(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cache.TryGet()
+	assert.False(t, ok, "An expired value should not be returned")
+}
+
+func TestValue_Get_SharedLoad(t *testing.T) {
+	var (
+		loads   atomic.Int64
+		release = make(chan struct{})
+		errLoad = errors.New("load failed")
+	)
+	cache := New(func() (string, error) {
+		loads.Add(1)
+		<-release
+		return "", errLoad
+	})
+
+	var (
+		wg      sync.WaitGroup
+		started sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		started.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			_, err := cache.Get()
+			assert.ErrorIs(t, err, errLoad)
+		}()
+	}
+	started.Wait()
+	// Give each goroutine time to start waiting on the load.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(1), loads.Load(), "Concurrent callers should share a single failed load")
+
+	// Subsequent calls should try again.
+	_, err := cache.Get()
+	assert.ErrorIs(t, err, errLoad)
+	assert.Equal(t, int64(2), loads.Load())
+}
@@ -0,0 +1,101 @@
+package cachehttp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/saylorsolutions/cache"
+)
+
+// Clearer is implemented by caches that can invalidate every value they hold, such as [cache.MultiCache] and [cache.ShardedMultiCache].
+type Clearer interface {
+	Clear()
+}
+
+// TagInvalidator is implemented by caches that can invalidate values by tag, such as [cache.MultiCache].
+type TagInvalidator interface {
+	InvalidateTag(tag string) int
+}
+
+var (
+	_ Clearer        = (*cache.MultiCache[string, string])(nil)
+	_ Clearer        = (*cache.ShardedMultiCache[string, string])(nil)
+	_ TagInvalidator = (*cache.MultiCache[string, string])(nil)
+)
+
+// AdminHandler returns an http.Handler that invalidates cached data in response to POST requests, so operators can purge bad data without redeploying.
+// The "cache" form value selects a registered cache by name, and exactly one of these form values selects what's invalidated:
+//   - "key" invalidates a single key, if the cache was registered with [RegisterKeyed].
+//   - "tag" invalidates every value with the tag, if the cache implements [TagInvalidator].
+//   - "all" set to "true" invalidates every value, if the cache implements [Clearer], or is a [cache.Value].
+//
+// A successful request will receive a 204 No Content response.
+// This handler can discard a cache's entire contents, so it must only be reachable by trusted operators.
+func (r *Registry) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, fmt.Sprintf("invalid form: %v", err), http.StatusBadRequest)
+			return
+		}
+		name := req.Form.Get("cache")
+		if name == "" {
+			http.Error(w, "cache is required", http.StatusBadRequest)
+			return
+		}
+		registered, ok := r.lookup(name)
+		if !ok {
+			http.Error(w, "unknown cache: "+name, http.StatusNotFound)
+			return
+		}
+
+		var actions int
+		for _, field := range []string{"key", "tag", "all"} {
+			if req.Form.Has(field) {
+				actions++
+			}
+		}
+		if actions != 1 {
+			http.Error(w, "exactly one of key, tag, or all is required", http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case req.Form.Has("key"):
+			if registered.invalidateKey == nil {
+				http.Error(w, "cache doesn't support invalidating keys: "+name, http.StatusBadRequest)
+				return
+			}
+			if err := registered.invalidateKey(req.Form.Get("key")); err != nil {
+				http.Error(w, fmt.Sprintf("invalid key: %v", err), http.StatusBadRequest)
+				return
+			}
+		case req.Form.Has("tag"):
+			invalidator, ok := registered.provider.(TagInvalidator)
+			if !ok {
+				http.Error(w, "cache doesn't support tags: "+name, http.StatusBadRequest)
+				return
+			}
+			invalidator.InvalidateTag(req.Form.Get("tag"))
+		default:
+			if req.Form.Get("all") != "true" {
+				http.Error(w, "all must be true", http.StatusBadRequest)
+				return
+			}
+			switch c := registered.provider.(type) {
+			case Clearer:
+				c.Clear()
+			case interface{ Invalidate() }:
+				c.Invalidate()
+			default:
+				http.Error(w, "cache doesn't support invalidating everything: "+name, http.StatusBadRequest)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,83 @@
+package cachehttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func postForm(handler http.Handler, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRegistry_AdminHandler(t *testing.T) {
+	users := cache.NewMulti[int, string](func(key int) (string, error) {
+		return "user", nil
+	})
+	users.SetTagger(func(key int, _ string) []string {
+		if key%2 == 0 {
+			return []string{"even"}
+		}
+		return nil
+	})
+	config := cache.New[string](func() (string, error) {
+		return "config", nil
+	})
+	registry := NewRegistry()
+	RegisterKeyed[int](registry, "users", users, IntKey)
+	registry.Register("config", config)
+	handler := registry.AdminHandler()
+	for i := 1; i <= 4; i++ {
+		users.MustGet(i)
+	}
+	config.MustGet()
+
+	rec := postForm(handler, url.Values{"cache": {"users"}, "key": {"1"}})
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Equal(t, 3, users.Len())
+
+	rec = postForm(handler, url.Values{"cache": {"users"}, "tag": {"even"}})
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Equal(t, []int{3}, users.Keys())
+
+	rec = postForm(handler, url.Values{"cache": {"users"}, "all": {"true"}})
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Equal(t, 0, users.Len())
+
+	rec = postForm(handler, url.Values{"cache": {"config"}, "all": {"true"}})
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	_, ok := config.TryGet()
+	assert.False(t, ok)
+
+	tests := map[string]struct {
+		form url.Values
+		code int
+	}{
+		"missing cache":     {url.Values{"key": {"1"}}, http.StatusBadRequest},
+		"unknown cache":     {url.Values{"cache": {"other"}, "key": {"1"}}, http.StatusNotFound},
+		"no action":         {url.Values{"cache": {"users"}}, http.StatusBadRequest},
+		"multiple actions":  {url.Values{"cache": {"users"}, "key": {"1"}, "tag": {"even"}}, http.StatusBadRequest},
+		"invalid key":       {url.Values{"cache": {"users"}, "key": {"one"}}, http.StatusBadRequest},
+		"key not supported": {url.Values{"cache": {"config"}, "key": {"1"}}, http.StatusBadRequest},
+		"tag not supported": {url.Values{"cache": {"config"}, "tag": {"even"}}, http.StatusBadRequest},
+		"all not true":      {url.Values{"cache": {"users"}, "all": {"yes"}}, http.StatusBadRequest},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.code, postForm(handler, tc.form).Code)
+		})
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?cache=users&all=true", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
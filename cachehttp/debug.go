@@ -0,0 +1,57 @@
+package cachehttp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/saylorsolutions/cache"
+)
+
+// CacheReport is the state of a single cache reported by the debug handler.
+type CacheReport struct {
+	Name  string
+	Stats cache.Stats
+	// Entries is only set when entries are requested, the handler allows them, and the cache implements [Inspector].
+	Entries []cache.EntryInfo `json:",omitempty"`
+}
+
+// DebugHandler returns an http.Handler that responds to GET requests with a JSON listing of each registered cache and its [cache.Stats].
+// The "cache" query parameter may be used to report on a single cache by name.
+//
+// If includeEntries is true, then the metadata of each entry will also be reported when the "entries" query parameter is "true".
+// Keys may contain sensitive information, so this should only be enabled where that's acceptable.
+func (r *Registry) DebugHandler(includeEntries bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		query := req.URL.Query()
+		names := r.names()
+		if name := query.Get("cache"); name != "" {
+			if _, ok := r.lookup(name); !ok {
+				http.Error(w, "unknown cache: "+name, http.StatusNotFound)
+				return
+			}
+			names = []string{name}
+		}
+		withEntries := includeEntries && query.Get("entries") == "true"
+
+		reports := make([]CacheReport, 0, len(names))
+		for _, name := range names {
+			registered, ok := r.lookup(name)
+			if !ok {
+				// Unregistered concurrently.
+				continue
+			}
+			report := CacheReport{Name: name, Stats: registered.provider.Stats()}
+			if inspector, ok := registered.provider.(Inspector); ok && withEntries {
+				report.Entries = inspector.Inspect()
+			}
+			reports = append(reports, report)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reports)
+	})
+}
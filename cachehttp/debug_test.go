@@ -0,0 +1,66 @@
+package cachehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() (*Registry, *cache.MultiCache[string, int]) {
+	users := cache.NewMulti[string, int](func(key string) (int, error) {
+		return len(key), nil
+	})
+	registry := NewRegistry()
+	registry.Register("users", users)
+	registry.Register("config", cache.New[string](func() (string, error) {
+		return "config", nil
+	}))
+	return registry, users
+}
+
+func getReports(t *testing.T, handler http.Handler, target string) []CacheReport {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var reports []CacheReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	return reports
+}
+
+func TestRegistry_DebugHandler(t *testing.T) {
+	registry, users := newTestRegistry()
+	users.MustGet("bob")
+	users.MustGet("bob")
+
+	reports := getReports(t, registry.DebugHandler(false), "/?entries=true")
+	require.Len(t, reports, 2)
+	assert.Equal(t, "config", reports[0].Name)
+	assert.Equal(t, "users", reports[1].Name)
+	assert.Equal(t, uint64(1), reports[1].Stats.Hits)
+	assert.Equal(t, 1, reports[1].Stats.Entries)
+	assert.Nil(t, reports[1].Entries, "entries should not be included unless allowed")
+
+	reports = getReports(t, registry.DebugHandler(true), "/?cache=users&entries=true")
+	require.Len(t, reports, 1)
+	require.Len(t, reports[0].Entries, 1)
+	assert.Equal(t, "bob", reports[0].Entries[0].Key)
+	assert.Equal(t, uint64(1), reports[0].Entries[0].Hits)
+
+	rec := httptest.NewRecorder()
+	registry.DebugHandler(true).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?cache=missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	registry.DebugHandler(true).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	registry.Unregister("config")
+	assert.Len(t, getReports(t, registry.DebugHandler(false), "/"), 1)
+}
@@ -0,0 +1,15 @@
+/*
+Package cachehttp provides HTTP handlers for inspecting caches, intended to be mounted on an internal admin mux.
+
+Caches are added to a [Registry] by name, and the Registry's handlers report on every cache it holds:
+
+	registry := cachehttp.NewRegistry()
+	cachehttp.RegisterKeyed[int](registry, "users", users, cachehttp.IntKey)
+	adminMux.Handle("/debug/cache", registry.DebugHandler(false))
+	adminMux.Handle("/admin/cache/invalidate", registry.AdminHandler())
+
+[Registry.DebugHandler] reports the stats, and optionally the entries, of each cache, while [Registry.AdminHandler] allows operators to invalidate a key, a tag, or an entire cache.
+
+These handlers expose internal state, so they should never be reachable from untrusted networks.
+*/
+package cachehttp
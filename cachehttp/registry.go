@@ -0,0 +1,124 @@
+package cachehttp
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/saylorsolutions/cache"
+)
+
+// Inspector is implemented by caches that can describe their entries, such as [cache.MultiCache] and [cache.ShardedMultiCache].
+type Inspector interface {
+	Inspect() []cache.EntryInfo
+}
+
+var (
+	_ Inspector = (*cache.MultiCache[string, string])(nil)
+	_ Inspector = (*cache.ShardedMultiCache[string, string])(nil)
+)
+
+// Registry holds named caches to be served by its handlers.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mux    sync.RWMutex
+	caches map[string]registered
+}
+
+// registered is a cache held by a Registry.
+type registered struct {
+	provider cache.StatsProvider
+	// invalidateKey parses and invalidates a key, and is only set for caches registered with RegisterKeyed.
+	invalidateKey func(key string) error
+}
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		caches: map[string]registered{},
+	}
+}
+
+// Register adds a cache to the Registry with the given name, replacing any cache with the same name.
+// If the cache implements [Inspector], then its entries may also be listed.
+//
+// This method will panic if provider is nil.
+func (r *Registry) Register(name string, provider cache.StatsProvider) {
+	if provider == nil {
+		panic("nil cache")
+	}
+	r.register(name, registered{provider: provider})
+}
+
+// KeyParser converts a key given in an HTTP request to a cache's key type.
+type KeyParser[K comparable] func(key string) (K, error)
+
+// StringKey is a [KeyParser] for caches with string keys.
+func StringKey(key string) (string, error) {
+	return key, nil
+}
+
+// IntKey is a [KeyParser] for caches with int keys.
+func IntKey(key string) (int, error) {
+	return strconv.Atoi(key)
+}
+
+// RegisterKeyed is the same as [Registry.Register], except that keys of the cache may also be invalidated by an [Registry.AdminHandler].
+// Keys given in requests are converted to the cache's key type with parseKey.
+//
+// This function will panic if c or parseKey is nil.
+func RegisterKeyed[K comparable](r *Registry, name string, c interface {
+	cache.StatsProvider
+	Invalidate(key K)
+}, parseKey KeyParser[K]) {
+	if c == nil {
+		panic("nil cache")
+	}
+	if parseKey == nil {
+		panic("nil key parser")
+	}
+	r.register(name, registered{
+		provider: c,
+		invalidateKey: func(key string) error {
+			parsed, err := parseKey(key)
+			if err != nil {
+				return err
+			}
+			c.Invalidate(parsed)
+			return nil
+		},
+	})
+}
+
+func (r *Registry) register(name string, entry registered) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.caches[name] = entry
+}
+
+// Unregister removes the cache with the given name from the Registry.
+func (r *Registry) Unregister(name string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.caches, name)
+}
+
+// lookup returns the cache registered with the given name.
+func (r *Registry) lookup(name string) (registered, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	entry, ok := r.caches[name]
+	return entry, ok
+}
+
+// names returns the name of every registered cache, in sorted order.
+func (r *Registry) names() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	names := make([]string, 0, len(r.caches))
+	for name := range r.caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,19 @@
+/*
+Package cacheotel instruments caches with OpenTelemetry metrics and tracing.
+
+[Metrics] observes the [cache.Stats] of any number of caches, each with a "cache" attribute set to the name it was added with.
+Load latency is recorded as a histogram, which is fed by the [Metrics.EventHandler]:
+
+	users := cache.NewMulti[int, *User](loadUser)
+	metrics, err := cacheotel.NewMetrics(otel.Meter("myapp"))
+	if err != nil {
+		return err
+	}
+	metrics.Add("users", users)
+	users.SetEventHandler("users", metrics.EventHandler())
+
+Loads can also be traced by wrapping a context-aware loader with [TraceLoader], so slow cache fills appear in the trace of the request that triggered them.
+
+This package is its own module, so OpenTelemetry isn't added to the dependencies of applications that only import [github.com/saylorsolutions/cache].
+*/
+package cacheotel
@@ -0,0 +1,122 @@
+package cacheotel
+
+import (
+	"context"
+	"sync"
+
+	"github.com/saylorsolutions/cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CacheKey is the attribute key used to identify the cache that a measurement belongs to.
+const CacheKey = attribute.Key("cache")
+
+// Metrics reports the statistics of caches added with Add as OpenTelemetry metrics.
+type Metrics struct {
+	mux    sync.RWMutex
+	caches map[string]cache.StatsProvider
+
+	hits         metric.Int64ObservableCounter
+	misses       metric.Int64ObservableCounter
+	loads        metric.Int64ObservableCounter
+	loadFailures metric.Int64ObservableCounter
+	evictions    metric.Int64ObservableCounter
+	staleServed  metric.Int64ObservableCounter
+	loadsShed    metric.Int64ObservableCounter
+	entries      metric.Int64ObservableGauge
+	loadDuration metric.Float64Histogram
+	registration metric.Registration
+}
+
+// NewMetrics creates the cache instruments with meter, and registers a callback to observe the statistics of each added cache.
+// An error will be returned if any instrument can't be created.
+func NewMetrics(meter metric.Meter) (*Metrics, error) {
+	var (
+		m = &Metrics{
+			caches: map[string]cache.StatsProvider{},
+		}
+		err error
+	)
+	counter := func(name, description string) metric.Int64ObservableCounter {
+		if err != nil {
+			return nil
+		}
+		var c metric.Int64ObservableCounter
+		c, err = meter.Int64ObservableCounter(name, metric.WithDescription(description))
+		return c
+	}
+	m.hits = counter("cache.hits", "Number of requests served by a valid cached value.")
+	m.misses = counter("cache.misses", "Number of requests that found no valid cached value.")
+	m.loads = counter("cache.loads", "Number of values loaded, including failed loads.")
+	m.loadFailures = counter("cache.load_failures", "Number of loads that returned an error.")
+	m.evictions = counter("cache.evictions", "Number of values removed because they expired or the cache was over capacity.")
+	m.staleServed = counter("cache.stale_served", "Number of times a previous value was returned in place of a load error.")
+	m.loadsShed = counter("cache.loads_shed", "Number of loads rejected because too many loads were in progress.")
+	if err != nil {
+		return nil, err
+	}
+	m.entries, err = meter.Int64ObservableGauge("cache.entries", metric.WithDescription("Number of valid values currently held."))
+	if err != nil {
+		return nil, err
+	}
+	m.loadDuration, err = meter.Float64Histogram("cache.load.duration", metric.WithDescription("Time taken to load values."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	m.registration, err = meter.RegisterCallback(m.observe, m.hits, m.misses, m.loads, m.loadFailures, m.evictions, m.staleServed, m.loadsShed, m.entries)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Add will report the statistics of provider with the given name.
+// Adding a cache with the same name as another cache will replace it.
+func (m *Metrics) Add(name string, provider cache.StatsProvider) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.caches[name] = provider
+}
+
+// Remove stops reporting the statistics of the cache added with the given name.
+func (m *Metrics) Remove(name string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	delete(m.caches, name)
+}
+
+// Close unregisters the callback used to observe cache statistics.
+// Load durations will still be recorded by the EventHandler.
+func (m *Metrics) Close() error {
+	return m.registration.Unregister()
+}
+
+// EventHandler returns a [cache.EventHandler] that records the duration of each load in the load duration histogram.
+// The source name given to SetEventHandler is used as the cache attribute, so it should match the name given to Add.
+func (m *Metrics) EventHandler() cache.EventHandler {
+	return func(event cache.Event) {
+		switch event.Type {
+		case cache.EventLoad, cache.EventLoadError:
+			m.loadDuration.Record(context.Background(), event.Duration.Seconds(), metric.WithAttributes(CacheKey.String(event.Source)))
+		}
+	}
+}
+
+func (m *Metrics) observe(_ context.Context, o metric.Observer) error {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	for name, provider := range m.caches {
+		stats := provider.Stats()
+		attrs := metric.WithAttributes(CacheKey.String(name))
+		o.ObserveInt64(m.hits, int64(stats.Hits), attrs)
+		o.ObserveInt64(m.misses, int64(stats.Misses), attrs)
+		o.ObserveInt64(m.loads, int64(stats.Loads), attrs)
+		o.ObserveInt64(m.loadFailures, int64(stats.LoadFailures), attrs)
+		o.ObserveInt64(m.evictions, int64(stats.Evictions), attrs)
+		o.ObserveInt64(m.staleServed, int64(stats.StaleServed), attrs)
+		o.ObserveInt64(m.loadsShed, int64(stats.LoadsShed), attrs)
+		o.ObserveInt64(m.entries, int64(stats.Entries), attrs)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package cacheotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics, err := NewMetrics(provider.Meter("test"))
+	require.NoError(t, err)
+
+	users := cache.NewMulti[int, string](func(key int) (string, error) {
+		return "user", nil
+	})
+	metrics.Add("users", users)
+	users.SetEventHandler("users", metrics.EventHandler())
+	users.MustGet(1)
+	users.MustGet(1)
+	users.MustGet(2)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+	require.Len(t, data.ScopeMetrics, 1)
+	values := map[string]int64{}
+	var loadDurations uint64
+	for _, m := range data.ScopeMetrics[0].Metrics {
+		switch d := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Len(t, d.DataPoints, 1)
+			name, ok := d.DataPoints[0].Attributes.Value(CacheKey)
+			assert.True(t, ok)
+			assert.Equal(t, "users", name.AsString())
+			values[m.Name] = d.DataPoints[0].Value
+		case metricdata.Gauge[int64]:
+			require.Len(t, d.DataPoints, 1)
+			values[m.Name] = d.DataPoints[0].Value
+		case metricdata.Histogram[float64]:
+			require.Len(t, d.DataPoints, 1)
+			loadDurations = d.DataPoints[0].Count
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"cache.hits":          1,
+		"cache.misses":        2,
+		"cache.loads":         2,
+		"cache.load_failures": 0,
+		"cache.evictions":     0,
+		"cache.stale_served":  0,
+		"cache.loads_shed":    0,
+		"cache.entries":       2,
+	}, values)
+	assert.Equal(t, uint64(2), loadDurations)
+
+	require.NoError(t, metrics.Close())
+}
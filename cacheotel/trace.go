@@ -0,0 +1,49 @@
+package cacheotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saylorsolutions/cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// KeyKey is the attribute key used to record the key being loaded.
+	KeyKey = attribute.Key("cache.key")
+	// FoundKey is the attribute key used to record whether the key being loaded exists, which is false if the loader returned [cache.ErrKeyNotFound].
+	FoundKey = attribute.Key("cache.found")
+)
+
+// TraceLoader wraps loader so that each load is recorded as a span created with tracer.
+// The span is a child of the context passed to [cache.MultiCache.GetCtx], so slow loads appear in the caller's trace.
+// Each span has the cache attribute set to name, and the key being loaded, formatted with fmt.Sprint.
+//
+// A span is marked as failed if the loader returns an error, unless the error is [cache.ErrKeyNotFound].
+//
+//	users := cache.NewMultiCtx[int, *User](cacheotel.TraceLoader(otel.Tracer("myapp"), "users", loadUser))
+func TraceLoader[K comparable, V any](tracer trace.Tracer, name string, loader cache.MultiLoaderCtxFunc[K, V]) cache.MultiLoaderCtxFunc[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return func(ctx context.Context, key K) (V, error) {
+		ctx, span := tracer.Start(ctx, "cache.load", trace.WithAttributes(
+			CacheKey.String(name),
+			KeyKey.String(fmt.Sprint(key)),
+		))
+		defer span.End()
+		val, err := loader(ctx, key)
+		switch {
+		case err == nil:
+			span.SetAttributes(FoundKey.Bool(true))
+		case cache.IsKeyNotFound(err):
+			span.SetAttributes(FoundKey.Bool(false))
+		default:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return val, err
+	}
+}
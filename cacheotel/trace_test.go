@@ -0,0 +1,55 @@
+package cacheotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceLoader(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	users := cache.NewMultiCtx[int, string](TraceLoader(tracer, "users", func(ctx context.Context, key int) (string, error) {
+		switch key {
+		case 0:
+			return "", cache.ErrKeyNotFound
+		case -1:
+			return "", errors.New("failed")
+		}
+		return "user", nil
+	}))
+
+	ctx, parent := tracer.Start(context.Background(), "request")
+	_, err := users.GetCtx(ctx, 1)
+	require.NoError(t, err)
+	_, err = users.GetCtx(ctx, 1)
+	require.NoError(t, err)
+	_, err = users.GetCtx(ctx, 0)
+	assert.ErrorIs(t, err, cache.ErrKeyNotFound)
+	_, err = users.GetCtx(ctx, -1)
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4, "cached values should not create spans")
+	for _, span := range spans[:3] {
+		assert.Equal(t, "cache.load", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), CacheKey.String("users"))
+	}
+	assert.Contains(t, spans[0].Attributes(), attribute.String("cache.key", "1"))
+	assert.Contains(t, spans[0].Attributes(), FoundKey.Bool(true))
+	assert.Contains(t, spans[1].Attributes(), FoundKey.Bool(false))
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
@@ -0,0 +1,121 @@
+package cacheprom
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/saylorsolutions/cache"
+)
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+const cacheLabel = "cache"
+
+// Collector is a [prometheus.Collector] that reports the statistics of caches added with Add.
+// Each metric has a "cache" label with the name the cache was added with.
+type Collector struct {
+	mux    sync.RWMutex
+	caches map[string]cache.StatsProvider
+
+	hits         *prometheus.Desc
+	misses       *prometheus.Desc
+	loads        *prometheus.Desc
+	loadFailures *prometheus.Desc
+	evictions    *prometheus.Desc
+	staleServed  *prometheus.Desc
+	loadsShed    *prometheus.Desc
+	entries      *prometheus.Desc
+	loadLatency  *prometheus.HistogramVec
+}
+
+// NewCollector creates a new Collector, where each metric name is prefixed with namespace.
+// Load latency uses the default Prometheus histogram buckets.
+func NewCollector(namespace string) *Collector {
+	return NewCollectorWithBuckets(namespace, prometheus.DefBuckets)
+}
+
+// NewCollectorWithBuckets is the same as NewCollector, except that the load latency histogram will use the given buckets, in seconds.
+func NewCollectorWithBuckets(namespace string, buckets []float64) *Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache", name), help, []string{cacheLabel}, nil)
+	}
+	return &Collector{
+		caches:       map[string]cache.StatsProvider{},
+		hits:         desc("hits_total", "Number of requests served by a valid cached value."),
+		misses:       desc("misses_total", "Number of requests that found no valid cached value."),
+		loads:        desc("loads_total", "Number of values loaded, including failed loads."),
+		loadFailures: desc("load_failures_total", "Number of loads that returned an error."),
+		evictions:    desc("evictions_total", "Number of values removed because they expired or the cache was over capacity."),
+		staleServed:  desc("stale_served_total", "Number of times a previous value was returned in place of a load error."),
+		loadsShed:    desc("loads_shed_total", "Number of loads rejected because too many loads were in progress."),
+		entries:      desc("entries", "Number of valid values currently held."),
+		loadLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "load_duration_seconds",
+			Help:      "Time taken to load values.",
+			Buckets:   buckets,
+		}, []string{cacheLabel}),
+	}
+}
+
+// Add will report the statistics of provider with the given name.
+// Adding a cache with the same name as another cache will replace it.
+func (c *Collector) Add(name string, provider cache.StatsProvider) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.caches[name] = provider
+}
+
+// Remove stops reporting the statistics of the cache added with the given name.
+func (c *Collector) Remove(name string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.caches, name)
+	c.loadLatency.DeleteLabelValues(name)
+}
+
+// EventHandler returns a [cache.EventHandler] that records the duration of each load in the load latency histogram.
+// The source name given to SetEventHandler is used as the cache label, so it should match the name given to Add.
+func (c *Collector) EventHandler() cache.EventHandler {
+	return func(event cache.Event) {
+		switch event.Type {
+		case cache.EventLoad, cache.EventLoadError:
+			c.loadLatency.WithLabelValues(event.Source).Observe(event.Duration.Seconds())
+		}
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.loads
+	ch <- c.loadFailures
+	ch <- c.evictions
+	ch <- c.staleServed
+	ch <- c.loadsShed
+	ch <- c.entries
+	c.loadLatency.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	for name, provider := range c.caches {
+		stats := provider.Stats()
+		counter := func(desc *prometheus.Desc, val uint64) {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(val), name)
+		}
+		counter(c.hits, stats.Hits)
+		counter(c.misses, stats.Misses)
+		counter(c.loads, stats.Loads)
+		counter(c.loadFailures, stats.LoadFailures)
+		counter(c.evictions, stats.Evictions)
+		counter(c.staleServed, stats.StaleServed)
+		counter(c.loadsShed, stats.LoadsShed)
+		ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Entries), name)
+	}
+	c.loadLatency.Collect(ch)
+}
@@ -0,0 +1,62 @@
+package cacheprom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	users := cache.NewMulti[int, string](func(key int) (string, error) {
+		if key < 0 {
+			return "", errors.New("invalid key")
+		}
+		return "user", nil
+	})
+	collector := NewCollector("test")
+	collector.Add("users", users)
+	users.SetEventHandler("users", collector.EventHandler())
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(collector))
+
+	users.MustGet(1)
+	users.MustGet(1)
+	_, err := users.Get(-1)
+	assert.Error(t, err)
+
+	expected := `
+# HELP test_cache_entries Number of valid values currently held.
+# TYPE test_cache_entries gauge
+test_cache_entries{cache="users"} 1
+# HELP test_cache_hits_total Number of requests served by a valid cached value.
+# TYPE test_cache_hits_total counter
+test_cache_hits_total{cache="users"} 1
+# HELP test_cache_load_failures_total Number of loads that returned an error.
+# TYPE test_cache_load_failures_total counter
+test_cache_load_failures_total{cache="users"} 1
+# HELP test_cache_loads_total Number of values loaded, including failed loads.
+# TYPE test_cache_loads_total counter
+test_cache_loads_total{cache="users"} 2
+# HELP test_cache_misses_total Number of requests that found no valid cached value.
+# TYPE test_cache_misses_total counter
+test_cache_misses_total{cache="users"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"test_cache_entries", "test_cache_hits_total", "test_cache_load_failures_total", "test_cache_loads_total", "test_cache_misses_total"))
+
+	count, err := testutil.GatherAndCount(reg, "test_cache_load_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	collector.Remove("users")
+	count, err = testutil.GatherAndCount(reg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
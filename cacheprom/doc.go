@@ -0,0 +1,15 @@
+/*
+Package cacheprom exports cache statistics as Prometheus metrics.
+
+A [Collector] reports the [cache.Stats] of any number of caches, each labeled with the name it was added with.
+Load latency is reported as a histogram, which is fed by the collector's [Collector.EventHandler]:
+
+	users := cache.NewMulti[int, *User](loadUser)
+	collector := cacheprom.NewCollector("myapp")
+	collector.Add("users", users)
+	users.SetEventHandler("users", collector.EventHandler())
+	prometheus.MustRegister(collector)
+
+This package is its own module, so the Prometheus client isn't added to the dependencies of applications that only import [github.com/saylorsolutions/cache].
+*/
+package cacheprom
@@ -0,0 +1,6 @@
+/*
+Package cachetest provides utilities for testing code that depends on the [cache.Cache] interface.
+
+A [Mock] behaves like a simple in-memory cache, but its responses may be scripted per key, and every call is recorded so tests can assert how the cache was used.
+*/
+package cachetest
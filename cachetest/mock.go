@@ -0,0 +1,181 @@
+package cachetest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/saylorsolutions/cache"
+)
+
+// ErrNotStubbed is returned from [Mock.Get] when the requested key has no stubbed result, no value has been set, and no GetFunc is set.
+var ErrNotStubbed = errors.New("cachetest: no result stubbed for key")
+
+var _ cache.Cache[string, string] = (*Mock[string, string])(nil)
+
+// Method identifies a method of the [cache.Cache] interface.
+type Method string
+
+const (
+	MethodGet        Method = "Get"
+	MethodSet        Method = "Set"
+	MethodInvalidate Method = "Invalidate"
+	MethodPreheat    Method = "Preheat"
+)
+
+// Call is a single recorded call to a [Mock].
+type Call[K comparable, V any] struct {
+	Method Method
+	// Keys holds the key passed to the method, or every key passed to Preheat.
+	Keys []K
+	// Value is the value passed to Set.
+	Value V
+}
+
+type stub[V any] struct {
+	val V
+	err error
+}
+
+// Mock is a scriptable implementation of [cache.Cache] for tests.
+// The zero value is not usable, so use NewMock to create one.
+//
+// By default, a Mock behaves like an in-memory cache that's only populated by Set.
+// Results for specific keys may be stubbed with Stub, and GetFunc may be set to handle any other key, like a loader.
+// Every call is recorded, and may be inspected with Calls and CallCount.
+type Mock[K comparable, V any] struct {
+	// GetFunc is called by Get for keys that haven't been stubbed or set.
+	// This must be set before the Mock is used concurrently.
+	GetFunc func(key K) (V, error)
+
+	mux    sync.Mutex
+	stubs  map[K]stub[V]
+	values map[K]V
+	calls  []Call[K, V]
+}
+
+// NewMock creates a new, empty Mock.
+func NewMock[K comparable, V any]() *Mock[K, V] {
+	return &Mock[K, V]{
+		stubs:  map[K]stub[V]{},
+		values: map[K]V{},
+	}
+}
+
+// Stub sets the result that Get will return for key.
+// A stubbed result takes precedence over values set with Set, and isn't affected by Invalidate.
+func (m *Mock[K, V]) Stub(key K, val V, err error) *Mock[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.stubs[key] = stub[V]{val: val, err: err}
+	return m
+}
+
+// Get returns the stubbed result for key, or the value set for key with Set.
+// Otherwise, GetFunc will be called if it's set, and its value will be cached if it returns no error.
+// If none of these apply, then ErrNotStubbed will be returned.
+func (m *Mock[K, V]) Get(key K) (V, error) {
+	m.mux.Lock()
+	m.record(Call[K, V]{Method: MethodGet, Keys: []K{key}})
+	m.mux.Unlock()
+	return m.lookup(key)
+}
+
+// lookup implements Get without recording the call.
+func (m *Mock[K, V]) lookup(key K) (V, error) {
+	m.mux.Lock()
+	if s, ok := m.stubs[key]; ok {
+		m.mux.Unlock()
+		return s.val, s.err
+	}
+	if val, ok := m.values[key]; ok {
+		m.mux.Unlock()
+		return val, nil
+	}
+	getFunc := m.GetFunc
+	m.mux.Unlock()
+
+	var mt V
+	if getFunc == nil {
+		return mt, fmt.Errorf("%w '%v'", ErrNotStubbed, key)
+	}
+	val, err := getFunc(key)
+	if err != nil {
+		return mt, err
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.values[key] = val
+	return val, nil
+}
+
+// Set records the call, and caches val for key.
+func (m *Mock[K, V]) Set(key K, val V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.record(Call[K, V]{Method: MethodSet, Keys: []K{key}, Value: val})
+	m.values[key] = val
+}
+
+// Invalidate records the call, and removes any value set for key.
+func (m *Mock[K, V]) Invalidate(key K) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.record(Call[K, V]{Method: MethodInvalidate, Keys: []K{key}})
+	delete(m.values, key)
+}
+
+// Preheat records the call, and then calls Get for each key, returning the first error encountered.
+// The calls to Get are not recorded.
+func (m *Mock[K, V]) Preheat(keys []K) error {
+	m.mux.Lock()
+	m.record(Call[K, V]{Method: MethodPreheat, Keys: append([]K(nil), keys...)})
+	m.mux.Unlock()
+	for _, key := range keys {
+		if _, err := m.lookup(key); err != nil {
+			return fmt.Errorf("error preheating cache with key '%v': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Calls returns every call made to the Mock, in order.
+func (m *Mock[K, V]) Calls() []Call[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	return append([]Call[K, V](nil), m.calls...)
+}
+
+// CallCount returns the number of calls made to method with key.
+// For Preheat, a call is counted if key was one of the keys passed.
+func (m *Mock[K, V]) CallCount(method Method, key K) int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	var count int
+	for _, call := range m.calls {
+		if call.Method != method {
+			continue
+		}
+		for _, k := range call.Keys {
+			if k == key {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// Reset clears all stubs, values, and recorded calls.
+func (m *Mock[K, V]) Reset() {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.stubs = map[K]stub[V]{}
+	m.values = map[K]V{}
+	m.calls = nil
+}
+
+// record must be called while holding the lock.
+func (m *Mock[K, V]) record(call Call[K, V]) {
+	m.calls = append(m.calls, call)
+}
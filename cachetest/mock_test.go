@@ -0,0 +1,59 @@
+package cachetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+// greeter is an example of application code that depends on the cache.Cache interface.
+type greeter struct {
+	names cache.Cache[int, string]
+}
+
+func (g *greeter) greet(id int) string {
+	name, err := g.names.Get(id)
+	if err != nil {
+		return "Hello, stranger"
+	}
+	return "Hello, " + name
+}
+
+func TestMock(t *testing.T) {
+	mock := NewMock[int, string]().
+		Stub(1, "Alice", nil).
+		Stub(2, "", errors.New("database unavailable"))
+	g := &greeter{names: mock}
+
+	assert.Equal(t, "Hello, Alice", g.greet(1))
+	assert.Equal(t, "Hello, stranger", g.greet(2))
+	assert.Equal(t, "Hello, stranger", g.greet(3))
+	assert.Equal(t, 1, mock.CallCount(MethodGet, 1))
+
+	_, err := mock.Get(3)
+	assert.ErrorIs(t, err, ErrNotStubbed)
+
+	mock.Set(3, "Carol")
+	assert.Equal(t, "Hello, Carol", g.greet(3))
+	mock.Invalidate(3)
+	assert.Equal(t, "Hello, stranger", g.greet(3))
+	assert.Equal(t, 1, mock.CallCount(MethodInvalidate, 3))
+
+	mock.GetFunc = func(key int) (string, error) {
+		return "Loaded", nil
+	}
+	assert.NoError(t, mock.Preheat([]int{4, 5}))
+	assert.Equal(t, 1, mock.CallCount(MethodPreheat, 5))
+	assert.Equal(t, 0, mock.CallCount(MethodGet, 5), "Preheat should not record calls to Get")
+	assert.Equal(t, "Hello, Loaded", g.greet(5))
+
+	calls := mock.Calls()
+	assert.Equal(t, MethodSet, calls[4].Method)
+	assert.Equal(t, "Carol", calls[4].Value)
+
+	mock.Reset()
+	assert.Empty(t, mock.Calls())
+	assert.Equal(t, "Hello, Loaded", g.greet(1))
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"fmt"
+)
+
+// childCache is the part of a child MultiCache used by its parent to cascade invalidation.
+type childCache[K comparable] interface {
+	Invalidate(key K)
+	Clear()
+}
+
+var _ childCache[string] = (*MultiCache[string, string])(nil)
+
+// Child returns the child of parent with the given name, and creates it with loader if it doesn't exist yet.
+// A child MultiCache shares its parent's keys, and is useful for caching values derived from the parent's values, such as a user's permissions alongside the user.
+// Whenever a key is invalidated in the parent, it will also be invalidated in each child, and clearing the parent with Clear will also clear each child.
+// A child may have children of its own, so invalidation will cascade through the whole hierarchy.
+//
+// If a child with the given name already exists with a different value type, or the loader is nil, then this function will panic.
+func Child[K comparable, V any, CV any](parent *MultiCache[K, V], name string, loader MultiLoaderFunc[K, CV]) *MultiCache[K, CV] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	var child *MultiCache[K, CV]
+	parent.updateHooks(func(hooks *multiHooks[K, V]) {
+		if existing, ok := hooks.children[name]; ok {
+			c, ok := existing.(*MultiCache[K, CV])
+			if !ok {
+				panic(fmt.Sprintf("child '%s' already exists with a different value type", name))
+			}
+			child = c
+			return
+		}
+		child = NewMulti[K, CV](loader)
+		children := make(map[string]childCache[K], len(hooks.children)+1)
+		for n, c := range hooks.children {
+			children[n] = c
+		}
+		children[name] = child
+		hooks.children = children
+	})
+	return child
+}
+
+// Clear will invalidate every value in the MultiCache, as well as every value in its child caches.
+func (m *MultiCache[K, V]) Clear() {
+	m.lock.Lock()
+	for key := range m.values {
+		m.remove(key, ReasonInvalidated)
+	}
+	m.unlock()
+	for _, child := range m.hooks.Load().children {
+		child.Clear()
+	}
+}
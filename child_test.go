@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChild(t *testing.T) {
+	users := NewMulti[int, string](func(id int) (string, error) {
+		return fmt.Sprintf("user-%d", id), nil
+	})
+	var permLoads int
+	perms := Child(users, "permissions", func(id int) ([]string, error) {
+		permLoads++
+		return []string{"read"}, nil
+	})
+	assert.Same(t, perms, Child(users, "permissions", func(id int) ([]string, error) {
+		return nil, nil
+	}), "The same child should be returned for the same name")
+	assert.Panics(t, func() {
+		Child(users, "permissions", func(id int) (int, error) {
+			return 0, nil
+		})
+	})
+	sessions := Child(perms, "sessions", func(id int) (string, error) {
+		return "session", nil
+	})
+
+	assert.NoError(t, users.Preheat([]int{1, 2}))
+	assert.NoError(t, perms.Preheat([]int{1, 2, 3}))
+	assert.NoError(t, sessions.Preheat([]int{1, 2}))
+	assert.Equal(t, 3, permLoads)
+
+	users.Invalidate(1)
+	assert.Equal(t, []int{2, 3}, sortedKeys(perms))
+	assert.Equal(t, []int{2}, sortedKeys(sessions), "Invalidation should cascade to grandchildren")
+
+	// Keys not held by the parent are still invalidated in children.
+	users.Invalidate(3)
+	assert.Equal(t, []int{2}, sortedKeys(perms))
+
+	users.Clear()
+	assert.Equal(t, 0, users.Len())
+	assert.Equal(t, 0, perms.Len())
+	assert.Equal(t, 0, sessions.Len())
+}
+
+func sortedKeys[V any](m *MultiCache[int, V]) []int {
+	keys := m.Keys()
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// delayedInvalidations tracks invalidations scheduled with InvalidateAfter.
+type delayedInvalidations[K comparable] struct {
+	mux    sync.Mutex
+	timers map[K]*time.Timer
+}
+
+// InvalidateAfter will invalidate the value for key once d has elapsed, as if Invalidate had been called at that time.
+// This is useful with eventually consistent data sources, where invalidating immediately after a write could just cache the stale data again.
+// Whatever is cached for key when d elapses is invalidated, even if it was loaded after InvalidateAfter was called.
+//
+// Calling InvalidateAfter again for the same key replaces the previously scheduled invalidation, and a scheduled invalidation may be cancelled with CancelInvalidateAfter.
+// If d <= 0, then the value is invalidated immediately.
+func (m *MultiCache[K, V]) InvalidateAfter(key K, d time.Duration) {
+	if d <= 0 {
+		m.CancelInvalidateAfter(key)
+		m.Invalidate(key)
+		return
+	}
+	key = m.normalize(key)
+	m.delayed.mux.Lock()
+	defer m.delayed.mux.Unlock()
+	if m.delayed.timers == nil {
+		m.delayed.timers = map[K]*time.Timer{}
+	}
+	if prev, ok := m.delayed.timers[key]; ok {
+		prev.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		m.delayed.mux.Lock()
+		if m.delayed.timers[key] != timer {
+			// Replaced or cancelled after the timer fired.
+			m.delayed.mux.Unlock()
+			return
+		}
+		delete(m.delayed.timers, key)
+		m.delayed.mux.Unlock()
+		m.Invalidate(key)
+	})
+	m.delayed.timers[key] = timer
+}
+
+// CancelInvalidateAfter cancels an invalidation for key scheduled with InvalidateAfter, and returns true if one was pending.
+func (m *MultiCache[K, V]) CancelInvalidateAfter(key K) bool {
+	key = m.normalize(key)
+	m.delayed.mux.Lock()
+	defer m.delayed.mux.Unlock()
+	timer, ok := m.delayed.timers[key]
+	if !ok {
+		return false
+	}
+	timer.Stop()
+	delete(m.delayed.timers, key)
+	return true
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_InvalidateAfter(t *testing.T) {
+	var loads atomic.Int64
+	mc := NewMulti[string, int64](func(key string) (int64, error) {
+		return loads.Add(1), nil
+	})
+	invalidated := make(chan string, 1)
+	mc.OnAnyInvalidate(func(key string) {
+		invalidated <- key
+	})
+
+	assert.Equal(t, int64(1), mc.MustGet("a"))
+	mc.InvalidateAfter("a", 20*time.Millisecond)
+	assert.Equal(t, int64(1), mc.MustGet("a"), "value should be cached until the grace period elapses")
+
+	select {
+	case key := <-invalidated:
+		assert.Equal(t, "a", key)
+	case <-time.After(time.Second):
+		t.Fatal("value was not invalidated")
+	}
+	assert.Equal(t, int64(2), mc.MustGet("a"))
+}
+
+func TestMultiCache_InvalidateAfter_Replace(t *testing.T) {
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		return key, nil
+	})
+	mc.Set("a", "set")
+	mc.InvalidateAfter("a", 10*time.Millisecond)
+	mc.InvalidateAfter("a", time.Hour)
+	time.Sleep(30 * time.Millisecond)
+	val, ok := mc.GetIfPresent("a")
+	assert.True(t, ok, "the first invalidation should have been replaced")
+	assert.Equal(t, "set", val)
+
+	assert.True(t, mc.CancelInvalidateAfter("a"))
+	assert.False(t, mc.CancelInvalidateAfter("a"))
+
+	mc.InvalidateAfter("a", 0)
+	_, ok = mc.GetIfPresent("a")
+	assert.False(t, ok)
+}
@@ -50,12 +50,91 @@ You may have a need to store many of the same values.
 The [MultiCache] is provided for this use-case.
 
 The MultiCache behaves similarly to the Value type, except that each underlying Value in a MultiCache is assigned a user-specified, comparable key.
+Like a Value, concurrent requests for the same missing key will share a single call to the loader, so a burst of requests for a cold key won't overwhelm the underlying data source.
 A MultiCache can contain more [MultiCache]'s if a sort of hierarchy is desired.
+For values derived from a MultiCache's values, [Child] creates a named child MultiCache with the same keys, where invalidating a key in the parent also invalidates it in the child.
 
 To eagerly load values into a MultiCache, use [MultiCache.Preheat] with a set of keys.
+If values have already been fetched, such as with a bulk query, then [MultiCache.PreheatFrom] will cache them without calling the loader.
+To avoid a cold start after a restart, a MultiCache may be saved with [MultiCache.Export] and restored with [MultiCache.Import], using a [Codec] set with [MultiCache.SetCodec].
+A point-in-time copy of a MultiCache can be made with [MultiCache.Clone], and the values of one MultiCache can be copied into another with [MultiCache.Merge].
+For reporting or bulk export, [MultiCache.Snapshot] returns a copy of every valid value as a map.
+If the MultiCache mirrors a small, fully known dataset, then a [KeyLister] may be set with [MultiCache.SetKeyLister], and [MultiCache.PreheatAll] will warm every key.
+If many values are needed at once, then [MultiCache.GetMulti] will return cached values and load any misses concurrently.
+A MultiCache created with [NewMultiBatch] will instead load all misses with a single call to its [MultiBatchLoaderFunc], which avoids making a request to the underlying data source for each key.
+Individual misses from concurrent calls to Get can also be coalesced into a single batch with [MultiCache.SetBatchWindow].
+To protect the underlying data source when a cold MultiCache receives many requests at once, [MultiCache.SetMaxConcurrentLoads] limits how many loads may run at the same time.
+Similarly, [MultiCache.SetLoadTimeout] keeps a load that never returns from blocking requests for its key indefinitely.
+During a backend incident, [MultiCache.SetLoadShedding] will fail requests fast with [ErrOverloaded] instead of adding more loads to a saturated data source.
 
 Note that setting a TTL on a MultiCache sets that policy for all newly added Values.
+To keep values that are loaded together from expiring together, [MultiCache.SetTTLPolicyJitter] will randomly vary each value's time to live.
+Rather than choosing a single time to live, [MultiCache.SetAdaptiveTTL] will lengthen the time to live of values that rarely change, and shorten it for values that change often.
+The policy may be overridden for individual keys with [MultiCache.SetKeyTTL], or a MultiCache may be created with [NewMultiWithTTL] to let the loader determine each value's time to live.
+How much longer a value will remain valid can be checked with [MultiCache.ExpiresAt] or [MultiCache.TTLRemaining].
+Expired values are reloaded when requested, but [MultiCache.RemoveExpired] may be used to periodically release values that are no longer being requested.
+Frequently read keys can be kept from going cold with [MultiCache.SetRefreshAhead], which reloads values in the background shortly before they expire.
+To avoid many callers reloading a popular value at once when it expires, [MultiCache.SetEarlyRefresh] will occasionally refresh values early, becoming more likely as their expiration approaches.
+Similarly, [MultiCache.SetStaleWhileRevalidate] will return a recently expired value immediately, and reload it in the background.
+If availability matters more than freshness, then [MultiCache.SetServeStaleOnError] will return the previous value for a key when reloading it fails.
+If lookups of keys that don't exist are common, then [MultiCache.SetNegativeTTL] will cache "not found" errors from the loader for a short time.
+For very large numbers of such keys, [MultiCache.SetMissingKeyFilter] remembers them in a fixed amount of memory, at the cost of occasionally reporting an existing key as missing.
+
+Errors from the loader are returned wrapped in a [LoadError], which records the key that failed to load.
+A loader should return [ErrKeyNotFound], or an error wrapping it, when a key doesn't exist, so that callers can tell it apart from a transient failure with [IsKeyNotFound].
+Alternatively, a MultiCache created with [NewMultiOptional] accepts a [MultiLoaderOptionalFunc], which reports absence by returning false instead of an error.
+Errors caused by how the cache was configured wrap [ErrMisconfigured].
 
 Also, MultiCache doesn't provide a means of setting the underlying persistence where cached values are sourced. This is the role of the [MultiLoaderFunc].
+If loads should respect request deadlines or carry tracing information, then [NewMultiCtx] accepts a [MultiLoaderCtxFunc], which receives the context passed to [MultiCache.GetCtx]. The cacheotel package can wrap such a loader to record each load as an OpenTelemetry span.
+If a value is already known, such as after it's been written to the underlying persistence, then it may be cached directly with [MultiCache.Set].
+
+If cached values contain slices, maps, or pointers that callers may modify, then [MultiCache.SetCloner] will give each caller its own copy.
+
+If a MultiCache will be warmed with many keys, then [NewMultiSized] will allocate space for them up front.
+If different keys may refer to the same value, such as usernames that only differ by case, then [MultiCache.SetKeyNormalizer] will convert each key to a canonical form before it's used.
+
+For write-heavy workloads on machines with many cores, [NewShardedMulti] creates a [ShardedMultiCache], which partitions keys across several MultiCache shards to reduce lock contention.
+Conversely, for small key sets that are read far more often than they change, [NewReadMostlyMulti] creates a [ReadMostlyMultiCache], which is backed by a sync.Map so reads never contend on a lock.
+Values that belong to groups, such as entities belonging to a tenant, can be cached with a [MultiCache2] created by [NewMulti2], where every value in a group may be invalidated at once with [MultiCache2.InvalidateGroup].
+
+# Depending on an interface
+
+[MultiCache], [ShardedMultiCache], and the buffered MultiCache all implement the [Cache] interface.
+Application code that depends on Cache can be unit tested with the scriptable Mock in [github.com/saylorsolutions/cache/cachetest], without real loaders.
+Code that should only read from a MultiCache can be given the view returned by [MultiCache.ReadOnly] instead.
+
+# Reacting to changes
+
+With eventually consistent data sources, [MultiCache.InvalidateAfter] will invalidate a key after a grace period, so data that's still stale isn't immediately cached again.
+To discard everything at once, such as after a deployment changes the shape of cached data, [MultiCache.BumpGeneration] logically invalidates every value without iterating over them.
+Related values can be grouped with tags, either assigned by a [Tagger] set with [MultiCache.SetTagger] or added with [MultiCache.Tag], and invalidated together with [MultiCache.InvalidateTag].
+
+To react to new values for a key, such as a configuration entry, [MultiCache.Watch] returns a channel that receives each value loaded or set for the key.
+
+[MultiCache.OnInvalidate] reacts to the invalidation of a single key, while [MultiCache.OnAnyInvalidate] reacts to the invalidation of any key, including keys added later.
+If cached values hold resources that must be released, then [MultiCache.OnEvict] will be called whenever a value is removed or replaced, along with the [Reason] it was removed.
+Where a value must be handled after it leaves the MultiCache, such as writing it back to a store, [MultiCache.Remove] deletes it without invalidation and returns what was cached.
+Aggregate counters such as hits, misses, and loads are available at any time with [MultiCache.Stats] or [Value.Stats], and [PublishExpvar] will serve them on /debug/vars.
+The cacheprom and cacheotel packages report the same statistics, along with load latency, as Prometheus or OpenTelemetry metrics.
+To alert on memory footprint, [MultiCache.EstimatedBytes] estimates the memory held by cached values, which can be made more accurate with [MultiCache.SetSizeEstimator].
+For observability and audit tooling, an [EventHandler] set with [MultiCache.SetEventHandler] or [Value.SetEventHandler] will receive a structured [Event] for each hit, miss, load, invalidation, and eviction.
+To inspect live state, such as for a bug report, [MultiCache.Dump] writes the age, remaining time to live, and hit count of each value.
+Admin tooling can also list values by how recently they were used with [MultiCache.ForEachByRecency], or by how soon they'll expire with [MultiCache.ForEachByExpiration].
+The cachehttp package serves the same information over HTTP for any number of named caches, and allows operators to invalidate cached data remotely.
+[LogEvents] creates an EventHandler that logs what a cache is doing with a logger such as slog, and [EventHandlers] allows events to be passed to several handlers.
+
+# Limiting MultiCache size
+
+By default, a MultiCache will hold every value it has loaded until it's invalidated.
+If keys may come from user input, then [MultiCache.SetMaxEntries] should be used to set an upper bound on the number of held values.
+Once this limit is reached, the least recently used values will be evicted to make room for new ones.
+If a small set of keys are accessed far more often than others, then [EvictLFU] may be set with [MultiCache.SetEvictionPolicy] to evict the least frequently used values instead.
+
+To keep memory proportional to the working set, [MultiCache.SetMaxIdle] will drop values that haven't been accessed recently, even if their time to live hasn't elapsed.
+
+If cached values vary greatly in size, then [MultiCache.SetMaxCost] can limit the MultiCache by the total cost of its values instead, as calculated by a [Weigher].
+
+Values that must always be available, such as the configuration of a default tenant, can be exempted from expiration and eviction with [MultiCache.Pin].
 */
 package cache
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// EntryInfo describes a value held in a MultiCache, for debugging and inspection.
+type EntryInfo struct {
+	Key any
+	// LoadedAt is the time that the value was loaded or set.
+	LoadedAt time.Time
+	// Age is the time since the value was loaded or set.
+	Age time.Duration
+	// ExpiresAt is the time that the value will expire, or the zero time if it has no time to live.
+	ExpiresAt time.Time
+	// TTL is the time remaining until the value expires, or 0 if it has no time to live.
+	TTL time.Duration
+	// Hits is the number of requests for the key that were served from the MultiCache, since the key was last added to it.
+	Hits uint64
+	// Pinned is true if the key is pinned with [MultiCache.Pin].
+	Pinned bool
+}
+
+// Inspect returns an [EntryInfo] for each valid value currently held in the MultiCache, ordered by the string representation of each key.
+// Like ForEach, this will not load or refresh any values, and values that are currently being loaded are skipped.
+func (m *MultiCache[K, V]) Inspect() []EntryInfo {
+	var (
+		now   = time.Now()
+		infos []EntryInfo
+	)
+	for _, ke := range m.entries() {
+		if _, ok := ke.entry.value.tryCached(); !ok {
+			continue
+		}
+		info := EntryInfo{
+			Key:    ke.key,
+			Hits:   ke.entry.hits.Load(),
+			Pinned: ke.entry.pinned.Load(),
+		}
+		if loaded := ke.entry.loadedAt.Load(); loaded > 0 {
+			info.LoadedAt = time.Unix(0, loaded)
+			info.Age = now.Sub(info.LoadedAt)
+		}
+		if at, ok, _ := ke.entry.value.tryExpiresAt(); ok {
+			info.ExpiresAt = at
+			info.TTL = at.Sub(now)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return fmt.Sprint(infos[i].Key) < fmt.Sprint(infos[j].Key)
+	})
+	return infos
+}
+
+// Dump writes a human-readable listing of the MultiCache's [Stats], and the key, age, remaining time to live, and hit count of each valid value to w.
+// This is intended for attaching to bug reports, or inspecting live state while debugging, and the format may change.
+// Values themselves are not written, since they may be large or sensitive.
+func (m *MultiCache[K, V]) Dump(w io.Writer) error {
+	stats := m.Stats()
+	if _, err := fmt.Fprintf(w, "entries=%d hits=%d misses=%d loads=%d load_failures=%d evictions=%d\n",
+		stats.Entries, stats.Hits, stats.Misses, stats.Loads, stats.LoadFailures, stats.Evictions); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KEY\tAGE\tTTL\tHITS\tPINNED")
+	for _, info := range m.Inspect() {
+		ttl := "-"
+		if !info.ExpiresAt.IsZero() {
+			ttl = info.TTL.Round(time.Millisecond).String()
+		}
+		_, _ = fmt.Fprintf(tw, "%v\t%s\t%s\t%d\t%t\n", info.Key, info.Age.Round(time.Millisecond), ttl, info.Hits, info.Pinned)
+	}
+	return tw.Flush()
+}
+
+// DumpJSON is the same as Dump, except that the stats and entries are written to w as a JSON object.
+func (m *MultiCache[K, V]) DumpJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(struct {
+		Stats   Stats
+		Entries []EntryInfo
+	}{
+		Stats:   m.Stats(),
+		Entries: m.Inspect(),
+	})
+}
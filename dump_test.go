@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_Inspect(t *testing.T) {
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		return len(key), nil
+	})
+	mc.SetKeyTTL("b", time.Minute)
+	mc.MustGet("b")
+	mc.MustGet("a")
+	mc.MustGet("a")
+	mc.MustGet("a")
+	mc.Pin("a")
+
+	infos := mc.Inspect()
+	require.Len(t, infos, 2)
+	assert.Equal(t, "a", infos[0].Key)
+	assert.Equal(t, uint64(2), infos[0].Hits)
+	assert.True(t, infos[0].Pinned)
+	assert.True(t, infos[0].ExpiresAt.IsZero())
+	assert.False(t, infos[0].LoadedAt.IsZero())
+	assert.GreaterOrEqual(t, infos[0].Age, time.Duration(0))
+
+	assert.Equal(t, "b", infos[1].Key)
+	assert.Equal(t, uint64(0), infos[1].Hits)
+	assert.False(t, infos[1].Pinned)
+	assert.InDelta(t, time.Minute, infos[1].TTL, float64(time.Second))
+}
+
+func TestMultiCache_Dump(t *testing.T) {
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		return len(key), nil
+	})
+	mc.SetTTLPolicy(time.Hour)
+	mc.MustGet("key")
+	mc.MustGet("key")
+
+	var buf bytes.Buffer
+	require.NoError(t, mc.Dump(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "entries=1 hits=1 misses=1 loads=1 load_failures=0 evictions=0", lines[0])
+	assert.Equal(t, []string{"KEY", "AGE", "TTL", "HITS", "PINNED"}, strings.Fields(lines[1]))
+	fields := strings.Fields(lines[2])
+	require.Len(t, fields, 5)
+	assert.Equal(t, "key", fields[0])
+	ttl, err := time.ParseDuration(fields[2])
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+	assert.Equal(t, "1", fields[3])
+
+	buf.Reset()
+	require.NoError(t, mc.DumpJSON(&buf))
+	var dumped struct {
+		Stats   Stats
+		Entries []EntryInfo
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &dumped))
+	assert.Equal(t, 1, dumped.Stats.Entries)
+	require.Len(t, dumped.Entries, 1)
+	assert.Equal(t, "key", dumped.Entries[0].Key)
+	assert.Equal(t, uint64(1), dumped.Entries[0].Hits)
+}
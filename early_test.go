@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetEarlyRefresh(t *testing.T) {
+	var loads atomic.Int32
+	mc := NewMulti[int, int32](func(key int) (int32, error) {
+		time.Sleep(10 * time.Millisecond)
+		return loads.Add(1), nil
+	})
+	mc.SetTTLPolicy(time.Second)
+	// A large beta makes an early refresh all but certain.
+	mc.SetEarlyRefresh(1000)
+
+	assert.Equal(t, int32(1), mc.MustGet(1))
+	assert.Equal(t, int32(1), mc.MustGet(1), "The cached value should be returned while refreshing")
+	assert.Eventually(t, func() bool {
+		return mc.MustGet(1) > 1
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Panics(t, func() {
+		mc.SetEarlyRefresh(0)
+	})
+}
+
+func TestMultiCache_SetEarlyRefresh_Set(t *testing.T) {
+	var loads atomic.Int32
+	mc := NewMulti[int, int32](func(key int) (int32, error) {
+		return loads.Add(1), nil
+	})
+	mc.SetTTLPolicy(time.Second)
+	mc.SetEarlyRefresh(1000)
+
+	// Values that have never been loaded have no load time to base a refresh on.
+	mc.Set(1, 0)
+	assert.Equal(t, int32(0), mc.MustGet(1))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(0), loads.Load())
+}
+
+func TestMultiCache_shouldRefreshEarly(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.SetTTLPolicy(time.Hour)
+	mc.MustGet(1)
+	entry := mc.values[1]
+	entry.loadDuration.Store(int64(time.Millisecond))
+
+	var refreshes int
+	for i := 0; i < 1000; i++ {
+		if mc.shouldRefreshEarly(entry, 1) {
+			refreshes++
+		}
+	}
+	assert.Equal(t, 0, refreshes, "A value far from expiring should not be refreshed")
+	assert.False(t, mc.shouldRefreshEarly(entry, 0))
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"errors"
+)
+
+var (
+	// ErrKeyNotFound should be returned (or wrapped) by a loader to report that a key doesn't exist in the underlying data source.
+	// This allows callers to distinguish a missing key from a transient load failure with [IsKeyNotFound].
+	// A MultiCache will never serve a stale value in place of ErrKeyNotFound, and will cache it as an absent key if [MultiCache.SetNegativeTTL] is used.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrMisconfigured is wrapped by errors that are caused by how a cache was configured, rather than a failure to load a value.
+	// For example, calling [MultiCache.PreheatAll] without a [KeyLister], or a [LoaderTTLFunc] returning a ttl <= 0.
+	ErrMisconfigured = errors.New("cache misconfigured")
+	// ErrLoadTimeout is wrapped by the error returned when a load takes longer than the timeout set with [MultiCache.SetLoadTimeout].
+	ErrLoadTimeout = errors.New("load timed out")
+	// ErrOverloaded is returned in place of loading a value when too many loads are already in progress.
+	// See [MultiCache.SetLoadShedding].
+	ErrOverloaded = errors.New("cache overloaded")
+)
+
+// IsKeyNotFound returns true if err is or wraps [ErrKeyNotFound].
+func IsKeyNotFound(err error) bool {
+	return errors.Is(err, ErrKeyNotFound)
+}
+
+// LoadError is returned from [MultiCache.Get] and related methods when the value for a key couldn't be loaded.
+// Err is the error returned from the loader, and LoadError's message is the same as Err's, so wrapping doesn't change what's logged.
+//
+// Use errors.As to find the key that failed to load, and errors.Is to inspect Err.
+type LoadError struct {
+	// Key is the key that failed to load.
+	Key any
+	// Err is the error returned from the loader.
+	Err error
+}
+
+func (e *LoadError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
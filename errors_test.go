@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadError(t *testing.T) {
+	transient := errors.New("connection reset")
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		switch key {
+		case "missing":
+			return "", fmt.Errorf("no row for %s: %w", key, ErrKeyNotFound)
+		case "flaky":
+			return "", transient
+		}
+		return key, nil
+	})
+
+	_, err := mc.Get("missing")
+	require.Error(t, err)
+	assert.True(t, IsKeyNotFound(err))
+	assert.Equal(t, "no row for missing: key not found", err.Error())
+
+	_, err = mc.Get("flaky")
+	require.Error(t, err)
+	assert.False(t, IsKeyNotFound(err))
+	assert.ErrorIs(t, err, transient)
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, "flaky", loadErr.Key)
+	assert.Same(t, transient, loadErr.Err)
+}
+
+func TestLoadError_NotServedStale(t *testing.T) {
+	var deleted bool
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		if deleted {
+			return "", ErrKeyNotFound
+		}
+		return "value", nil
+	})
+	mc.SetTTLPolicy(time.Millisecond)
+	mc.SetServeStaleOnError(true)
+	assert.Equal(t, "value", mc.MustGet("a"))
+
+	deleted = true
+	time.Sleep(5 * time.Millisecond)
+	_, err := mc.Get("a")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestLoadError_Batch(t *testing.T) {
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		return map[int]int{1: 1}, nil
+	})
+	_, err := mc.GetMulti([]int{1, 2})
+	assert.True(t, IsKeyNotFound(err))
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, 2, loadErr.Key)
+}
+
+func TestErrMisconfigured(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	err := mc.PreheatAll(context.Background())
+	assert.ErrorIs(t, err, ErrMisconfigured)
+
+	ttlCache := NewMultiWithTTL[int, int](func(key int) (int, time.Duration, error) {
+		return key, 0, nil
+	})
+	_, err = ttlCache.Get(1)
+	assert.ErrorIs(t, err, ErrMisconfigured)
+}
+
+func TestNewMultiOptional(t *testing.T) {
+	var loads int
+	mc := NewMultiOptional[string, int](func(key string) (int, bool, error) {
+		loads++
+		switch key {
+		case "missing":
+			return 0, false, nil
+		case "broken":
+			return 0, false, errors.New("broken")
+		}
+		return len(key), true, nil
+	})
+	mc.SetNegativeTTL(time.Minute, func(err error) bool {
+		return false
+	})
+
+	assert.Equal(t, 3, mc.MustGet("abc"))
+
+	_, err := mc.Get("missing")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = mc.Get("missing")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, 2, loads, "absence should be cached")
+
+	_, err = mc.Get("broken")
+	assert.Error(t, err)
+	assert.False(t, IsKeyNotFound(err))
+
+	assert.Panics(t, func() {
+		NewMultiOptional[string, int](nil)
+	})
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies the kind of an [Event].
+type EventType int
+
+const (
+	// EventHit is emitted when a request is served by a valid cached value.
+	EventHit EventType = iota
+	// EventMiss is emitted when a request finds no valid cached value.
+	EventMiss
+	// EventLoad is emitted when a value is successfully loaded.
+	EventLoad
+	// EventLoadError is emitted when a load returns an error.
+	EventLoadError
+	// EventInvalidate is emitted when a value is invalidated.
+	EventInvalidate
+	// EventEvict is emitted when a value is removed for any reason other than invalidation, such as expiration or capacity.
+	EventEvict
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventHit:
+		return "hit"
+	case EventMiss:
+		return "miss"
+	case EventLoad:
+		return "load"
+	case EventLoadError:
+		return "load error"
+	case EventInvalidate:
+		return "invalidate"
+	case EventEvict:
+		return "evict"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event is a structured record of something that happened in a cache.
+// Events from every cache type share this structure, so observability and audit tooling can consume a single stream.
+type Event struct {
+	Type EventType
+	// Source is the name given to the cache when its EventHandler was set.
+	Source string
+	// Key is the key of the affected value, or nil for a [Value].
+	Key any
+	// Reason is set for EventEvict and EventInvalidate events.
+	Reason Reason
+	// Err is set for EventLoadError events.
+	Err error
+	// Duration is how long the load took, and is set for EventLoad and EventLoadError events.
+	Duration time.Duration
+	Time     time.Time
+}
+
+// EventHandler receives events emitted by a cache.
+// An EventHandler is called synchronously, so it should return quickly.
+// It's called without holding any cache locks, so it may use the cache that emitted the event.
+type EventHandler func(event Event)
+
+// EventChannel returns an EventHandler that sends each event to ch.
+// Events are dropped rather than blocking the cache if ch is full, so ch should be buffered.
+func EventChannel(ch chan<- Event) EventHandler {
+	return func(event Event) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// EventHandlers returns an EventHandler that passes each event to every non-nil handler in handlers, in order.
+// This allows a cache's events to be consumed by several handlers, such as metrics and logging.
+func EventHandlers(handlers ...EventHandler) EventHandler {
+	var active []EventHandler
+	for _, handler := range handlers {
+		if handler != nil {
+			active = append(active, handler)
+		}
+	}
+	return func(event Event) {
+		for _, handler := range active {
+			handler(event)
+		}
+	}
+}
+
+// eventSink pairs an EventHandler with the source name used for its events.
+type eventSink struct {
+	source  string
+	handler EventHandler
+}
+
+// emit calls the handler with an event of the given type, if the sink is set.
+func (s *eventSink) emit(event Event) {
+	if s == nil || s.handler == nil {
+		return
+	}
+	event.Source = s.source
+	event.Time = time.Now()
+	s.handler(event)
+}
+
+// SetEventHandler sets a handler that will receive an [Event] for each hit, miss, load, invalidation, and eviction in the MultiCache.
+// The source name is included in each event, so one handler may be shared between many caches.
+// A nil handler will stop events from being emitted.
+func (m *MultiCache[K, V]) SetEventHandler(source string, handler EventHandler) {
+	var sink *eventSink
+	if handler != nil {
+		sink = &eventSink{source: source, handler: handler}
+	}
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.events = sink
+	})
+}
+
+// recordLoad counts a load for key that took the given duration, and emits its event.
+func (m *MultiCache[K, V]) recordLoad(key K, err error, duration time.Duration) {
+	m.stats.recordLoads(1, err)
+	events := m.hooks.Load().events
+	if err != nil {
+		events.emit(Event{Type: EventLoadError, Key: key, Err: err, Duration: duration})
+		return
+	}
+	events.emit(Event{Type: EventLoad, Key: key, Duration: duration})
+}
+
+// SetEventHandler sets a handler that will receive an [Event] for each hit, miss, load, and invalidation of the Value.
+// The source name is included in each event, so one handler may be shared between many caches.
+// A nil handler will stop events from being emitted.
+func (c *Value[T]) SetEventHandler(source string, handler EventHandler) {
+	var sink *eventSink
+	if handler != nil {
+		sink = &eventSink{source: source, handler: handler}
+	}
+	c.events.Store(sink)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetEventHandler(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key, nil
+	})
+	events := make(chan Event, 32)
+	mc.SetEventHandler("numbers", EventChannel(events))
+	mc.SetMaxEntries(2)
+
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(-1)
+	_, _ = mc.Get(2)
+	mc.Invalidate(2)
+
+	var types []EventType
+	close(events)
+	for event := range events {
+		assert.Equal(t, "numbers", event.Source)
+		assert.False(t, event.Time.IsZero())
+		types = append(types, event.Type)
+		if event.Type == EventLoadError {
+			assert.Equal(t, -1, event.Key)
+			assert.Error(t, event.Err)
+		}
+		if event.Type == EventEvict {
+			assert.Equal(t, ReasonCapacity, event.Reason)
+		}
+	}
+	assert.Equal(t, []EventType{
+		EventMiss, EventLoad,
+		EventHit,
+		EventMiss, EventLoadError,
+		EventEvict, EventMiss, EventLoad,
+		EventInvalidate,
+	}, types)
+}
+
+func TestValue_SetEventHandler(t *testing.T) {
+	var received []Event
+	c := New(func() (string, error) {
+		return "value", nil
+	})
+	c.SetEventHandler("value", func(event Event) {
+		received = append(received, event)
+	})
+	_, _ = c.Get()
+	_, _ = c.Get()
+	c.Invalidate()
+
+	var types []EventType
+	for _, event := range received {
+		assert.Nil(t, event.Key)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []EventType{EventMiss, EventLoad, EventHit, EventInvalidate}, types)
+	assert.Equal(t, "load error", EventLoadError.String())
+}
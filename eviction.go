@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"container/heap"
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// EvictionPolicy determines which values are evicted first when a MultiCache is over capacity.
+type EvictionPolicy int
+
+const (
+	// EvictLRU will evict the least recently used value first.
+	// This is the default policy.
+	EvictLRU EvictionPolicy = iota
+	// EvictLFU will evict the least frequently used value first.
+	// Values with the same number of accesses are evicted in least recently used order.
+	// This performs better than EvictLRU for skewed access distributions, where a small set of keys are accessed far more often than others.
+	EvictLFU
+)
+
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictLRU:
+		return "LRU"
+	case EvictLFU:
+		return "LFU"
+	default:
+		return fmt.Sprintf("EvictionPolicy(%d)", int(p))
+	}
+}
+
+func newEvictor[K comparable](policy EvictionPolicy) evictor[K] {
+	switch policy {
+	case EvictLRU:
+		return newLRUPolicy[K]()
+	case EvictLFU:
+		return newLFUPolicy[K]()
+	default:
+		panic(fmt.Sprintf("unknown eviction policy: %s", policy))
+	}
+}
+
+// evictor tracks key usage in a MultiCache to determine which key should be evicted when the MultiCache is over capacity.
+// Implementations must be goroutine-safe, since access may be recorded while the MultiCache only holds a read lock.
+type evictor[K comparable] interface {
+	// add starts tracking a newly cached key.
+	add(key K)
+	// touch records an access of a tracked key.
+	touch(key K)
+	// remove stops tracking a key.
+	remove(key K)
+	// victim returns the key that should be evicted next, or false if no keys are tracked.
+	victim() (K, bool)
+	// victimExcept is like victim, but will never return the given key.
+	victimExcept(key K) (K, bool)
+}
+
+var _ evictor[string] = (*lruPolicy[string])(nil)
+
+// lruPolicy will evict the least recently used key first.
+type lruPolicy[K comparable] struct {
+	mux      sync.Mutex
+	order    *list.List
+	elements map[K]*list.Element
+}
+
+func newLRUPolicy[K comparable]() *lruPolicy[K] {
+	return &lruPolicy[K]{
+		order:    list.New(),
+		elements: map[K]*list.Element{},
+	}
+}
+
+func (p *lruPolicy[K]) add(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if elem, ok := p.elements[key]; ok {
+		p.order.MoveToFront(elem)
+		return
+	}
+	p.elements[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy[K]) touch(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if elem, ok := p.elements[key]; ok {
+		p.order.MoveToFront(elem)
+	}
+}
+
+func (p *lruPolicy[K]) remove(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if elem, ok := p.elements[key]; ok {
+		p.order.Remove(elem)
+		delete(p.elements, key)
+	}
+}
+
+func (p *lruPolicy[K]) victim() (K, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	elem := p.order.Back()
+	if elem == nil {
+		var mt K
+		return mt, false
+	}
+	return elem.Value.(K), true
+}
+
+func (p *lruPolicy[K]) victimExcept(key K) (K, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for elem := p.order.Back(); elem != nil; elem = elem.Prev() {
+		if victim := elem.Value.(K); victim != key {
+			return victim, true
+		}
+	}
+	var mt K
+	return mt, false
+}
+
+var _ evictor[string] = (*lfuPolicy[string])(nil)
+
+type lfuEntry[K comparable] struct {
+	key   K
+	freq  uint64
+	tick  uint64
+	index int
+}
+
+// lfuHeap orders entries by access frequency, then by recency of access.
+type lfuHeap[K comparable] []*lfuEntry[K]
+
+func (h lfuHeap[K]) Len() int {
+	return len(h)
+}
+
+func (h lfuHeap[K]) Less(i, j int) bool {
+	if h[i].freq == h[j].freq {
+		return h[i].tick < h[j].tick
+	}
+	return h[i].freq < h[j].freq
+}
+
+func (h lfuHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap[K]) Push(x any) {
+	entry := x.(*lfuEntry[K])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
+
+// lfuPolicy will evict the least frequently used key first.
+type lfuPolicy[K comparable] struct {
+	mux     sync.Mutex
+	tick    uint64
+	entries map[K]*lfuEntry[K]
+	order   lfuHeap[K]
+}
+
+func newLFUPolicy[K comparable]() *lfuPolicy[K] {
+	return &lfuPolicy[K]{
+		entries: map[K]*lfuEntry[K]{},
+	}
+}
+
+func (p *lfuPolicy[K]) add(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.tick++
+	if entry, ok := p.entries[key]; ok {
+		entry.freq++
+		entry.tick = p.tick
+		heap.Fix(&p.order, entry.index)
+		return
+	}
+	entry := &lfuEntry[K]{key: key, freq: 1, tick: p.tick}
+	p.entries[key] = entry
+	heap.Push(&p.order, entry)
+}
+
+func (p *lfuPolicy[K]) touch(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	entry, ok := p.entries[key]
+	if !ok {
+		return
+	}
+	p.tick++
+	entry.freq++
+	entry.tick = p.tick
+	heap.Fix(&p.order, entry.index)
+}
+
+func (p *lfuPolicy[K]) remove(key K) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	entry, ok := p.entries[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&p.order, entry.index)
+	delete(p.entries, key)
+}
+
+func (p *lfuPolicy[K]) victim() (K, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if len(p.order) == 0 {
+		var mt K
+		return mt, false
+	}
+	return p.order[0].key, true
+}
+
+func (p *lfuPolicy[K]) victimExcept(key K) (K, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	var mt K
+	if len(p.order) == 0 {
+		return mt, false
+	}
+	if p.order[0].key != key {
+		return p.order[0].key, true
+	}
+	// The next least frequently used entry must be one of the root's children.
+	switch len(p.order) {
+	case 1:
+		return mt, false
+	case 2:
+		return p.order[1].key, true
+	default:
+		if p.order.Less(2, 1) {
+			return p.order[2].key, true
+		}
+		return p.order[1].key, true
+	}
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLRUPolicy(t *testing.T) {
+	policy := newLRUPolicy[string]()
+	_, ok := policy.victim()
+	assert.False(t, ok, "No victim should be found for an empty policy")
+
+	policy.add("a")
+	policy.add("b")
+	policy.add("c")
+	victim, ok := policy.victim()
+	assert.True(t, ok)
+	assert.Equal(t, "a", victim)
+
+	policy.touch("a")
+	victim, _ = policy.victim()
+	assert.Equal(t, "b", victim, "Touching a should make b the least recently used")
+
+	policy.remove("b")
+	victim, _ = policy.victim()
+	assert.Equal(t, "c", victim)
+}
+
+func TestLFUPolicy(t *testing.T) {
+	policy := newLFUPolicy[string]()
+	_, ok := policy.victim()
+	assert.False(t, ok, "No victim should be found for an empty policy")
+
+	policy.add("a")
+	policy.add("b")
+	policy.add("c")
+	victim, ok := policy.victim()
+	assert.True(t, ok)
+	assert.Equal(t, "a", victim, "Equal frequencies should fall back to least recently used")
+
+	policy.touch("a")
+	policy.touch("a")
+	policy.touch("b")
+	victim, _ = policy.victim()
+	assert.Equal(t, "c", victim, "c is the least frequently used")
+
+	policy.remove("c")
+	victim, _ = policy.victim()
+	assert.Equal(t, "b", victim)
+}
+
+func TestPolicy_VictimExcept(t *testing.T) {
+	policies := map[string]evictor[string]{
+		"LRU": newLRUPolicy[string](),
+		"LFU": newLFUPolicy[string](),
+	}
+	for name, policy := range policies {
+		policy := policy
+		t.Run(name, func(t *testing.T) {
+			policy.add("a")
+			_, ok := policy.victimExcept("a")
+			assert.False(t, ok, "The only tracked key is excluded")
+
+			policy.add("b")
+			policy.add("c")
+			victim, ok := policy.victimExcept("a")
+			assert.True(t, ok)
+			assert.Equal(t, "b", victim)
+		})
+	}
+}
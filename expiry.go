@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+type expiryItem[K comparable] struct {
+	key   K
+	at    time.Time
+	index int
+}
+
+// expiryHeap orders items by soonest expiration.
+type expiryHeap[K comparable] []*expiryItem[K]
+
+func (h expiryHeap[K]) Len() int {
+	return len(h)
+}
+
+func (h expiryHeap[K]) Less(i, j int) bool {
+	return h[i].at.Before(h[j].at)
+}
+
+func (h expiryHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap[K]) Push(x any) {
+	item := x.(*expiryItem[K])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *expiryHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+// expiryQueue indexes keys by their scheduled expiration, so the soonest expiration can be found in constant time, and updated in O(log n) time.
+// A scheduled expiration may become stale if a Value's expiration is refreshed, so users of an expiryQueue must verify the actual expiration of a Value before acting on it.
+type expiryQueue[K comparable] struct {
+	mux   sync.Mutex
+	items map[K]*expiryItem[K]
+	order expiryHeap[K]
+}
+
+// newExpiryQueue creates an expiryQueue with space allocated for size keys.
+func newExpiryQueue[K comparable](size int) *expiryQueue[K] {
+	return &expiryQueue[K]{
+		items: make(map[K]*expiryItem[K], size),
+		order: make(expiryHeap[K], 0, size),
+	}
+}
+
+// schedule sets the expiration time for the given key, replacing any previously scheduled time.
+func (q *expiryQueue[K]) schedule(key K, at time.Time) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if item, ok := q.items[key]; ok {
+		item.at = at
+		heap.Fix(&q.order, item.index)
+		return
+	}
+	item := &expiryItem[K]{key: key, at: at}
+	q.items[key] = item
+	heap.Push(&q.order, item)
+}
+
+// unschedule removes the key from the queue, if it's present.
+func (q *expiryQueue[K]) unschedule(key K) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	item, ok := q.items[key]
+	if !ok {
+		return
+	}
+	heap.Remove(&q.order, item.index)
+	delete(q.items, key)
+}
+
+// next returns the key with the soonest scheduled expiration, or false if the queue is empty.
+func (q *expiryQueue[K]) next() (K, time.Time, bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if len(q.order) == 0 {
+		var mt K
+		return mt, time.Time{}, false
+	}
+	item := q.order[0]
+	return item.key, item.at, true
+}
+
+// popDue removes and returns every key with a scheduled expiration at or before now, along with its scheduled time.
+func (q *expiryQueue[K]) popDue(now time.Time) []*expiryItem[K] {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	var due []*expiryItem[K]
+	for len(q.order) > 0 && !q.order[0].at.After(now) {
+		item := heap.Pop(&q.order).(*expiryItem[K])
+		delete(q.items, item.key)
+		due = append(due, item)
+	}
+	return due
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExpiryQueue(t *testing.T) {
+	var (
+		q   = newExpiryQueue[string](0)
+		now = time.Now()
+	)
+	_, _, ok := q.next()
+	assert.False(t, ok, "An empty queue should have no next item")
+
+	q.schedule("a", now.Add(3*time.Second))
+	q.schedule("b", now.Add(time.Second))
+	q.schedule("c", now.Add(2*time.Second))
+	key, at, ok := q.next()
+	assert.True(t, ok)
+	assert.Equal(t, "b", key)
+	assert.Equal(t, now.Add(time.Second), at)
+
+	q.schedule("b", now.Add(4*time.Second))
+	key, _, _ = q.next()
+	assert.Equal(t, "c", key, "Rescheduling b should have moved it to the back")
+
+	q.unschedule("c")
+	key, _, _ = q.next()
+	assert.Equal(t, "a", key)
+	assert.Len(t, q.order, 2)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ExportedEntry is a single cached value written by [MultiCache.Export] and read by [MultiCache.Import].
+type ExportedEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+	// ExpiresAt is the time that the value will expire, or the zero time if it has no time to live.
+	ExpiresAt time.Time
+}
+
+// Codec encodes and decodes the entries of a MultiCache for Export and Import.
+type Codec[K comparable, V any] interface {
+	Encode(w io.Writer, entries []ExportedEntry[K, V]) error
+	Decode(r io.Reader) ([]ExportedEntry[K, V], error)
+}
+
+var (
+	_ Codec[string, string] = GobCodec[string, string]{}
+	_ Codec[string, string] = JSONCodec[string, string]{}
+)
+
+// GobCodec is a [Codec] that uses [encoding/gob].
+// This is the default Codec used by a MultiCache.
+type GobCodec[K comparable, V any] struct{}
+
+func (GobCodec[K, V]) Encode(w io.Writer, entries []ExportedEntry[K, V]) error {
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+func (GobCodec[K, V]) Decode(r io.Reader) ([]ExportedEntry[K, V], error) {
+	var entries []ExportedEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// JSONCodec is a [Codec] that uses [encoding/json].
+// Note that JSON only supports keys and values that can be represented as JSON, and some type information, such as the exact numeric type of an interface value, may be lost.
+type JSONCodec[K comparable, V any] struct{}
+
+func (JSONCodec[K, V]) Encode(w io.Writer, entries []ExportedEntry[K, V]) error {
+	return json.NewEncoder(w).Encode(entries)
+}
+
+func (JSONCodec[K, V]) Decode(r io.Reader) ([]ExportedEntry[K, V], error) {
+	var entries []ExportedEntry[K, V]
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SetCodec sets the [Codec] used by Export and Import.
+// By default, a MultiCache will use a [GobCodec].
+// This method will panic if codec is nil.
+func (m *MultiCache[K, V]) SetCodec(codec Codec[K, V]) {
+	if codec == nil {
+		panic("nil codec")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.codec = codec
+}
+
+func (m *MultiCache[K, V]) getCodec() Codec[K, V] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if m.codec == nil {
+		return GobCodec[K, V]{}
+	}
+	return m.codec
+}
+
+// Export writes every valid value in the MultiCache to w, along with its expiration.
+// This allows a service to persist its warm cache at shutdown, and restore it with Import at startup to avoid a spike of loads.
+// Values that are expired or currently loading are skipped.
+func (m *MultiCache[K, V]) Export(w io.Writer) error {
+	var exported []ExportedEntry[K, V]
+	for _, ke := range m.entries() {
+		val, at, ok := ke.entry.value.cachedUntil()
+		if !ok {
+			continue
+		}
+		exported = append(exported, ExportedEntry[K, V]{
+			Key:       ke.key,
+			Value:     val,
+			ExpiresAt: at,
+		})
+	}
+	if err := m.getCodec().Encode(w, exported); err != nil {
+		return fmt.Errorf("error exporting cache: %w", err)
+	}
+	return nil
+}
+
+// Import reads entries written by Export from r, and caches each one without calling the loader.
+// Entries that have expired since they were exported are skipped, and an imported value will never be valid for longer than it would have been in the exporting MultiCache.
+// The number of values imported is returned.
+func (m *MultiCache[K, V]) Import(r io.Reader) (int, error) {
+	entries, err := m.getCodec().Decode(r)
+	if err != nil {
+		return 0, fmt.Errorf("error importing cache: %w", err)
+	}
+	var (
+		now      = time.Now()
+		imported int
+	)
+	for _, e := range entries {
+		if !e.ExpiresAt.IsZero() && !e.ExpiresAt.After(now) {
+			continue
+		}
+		m.Set(e.Key, e.Value)
+		if !e.ExpiresAt.IsZero() {
+			m.expireBy(e.Key, e.ExpiresAt)
+		}
+		imported++
+	}
+	return imported, nil
+}
+
+// expireBy moves the expiration of the value for key earlier to at, if it would otherwise expire later.
+func (m *MultiCache[K, V]) expireBy(key K, at time.Time) {
+	m.lock.RLock()
+	entry, ok := m.values[key]
+	m.lock.RUnlock()
+	if ok && entry.value.expireBy(at) {
+		m.expirations.schedule(key, at)
+	}
+}
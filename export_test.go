@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_Export(t *testing.T) {
+	for name, codec := range map[string]Codec[int, string]{
+		"gob":  GobCodec[int, string]{},
+		"json": JSONCodec[int, string]{},
+	} {
+		codec := codec
+		t.Run(name, func(t *testing.T) {
+			source := NewMulti[int, string](func(key int) (string, error) {
+				return fmt.Sprintf("value-%d", key), nil
+			})
+			source.SetCodec(codec)
+			source.SetTTLPolicy(time.Hour)
+			require.NoError(t, source.Preheat([]int{1, 2, 3}))
+			source.SetKeyTTL(3, 10*time.Millisecond)
+
+			var buf bytes.Buffer
+			require.NoError(t, source.Export(&buf))
+			time.Sleep(20 * time.Millisecond)
+
+			var loads int
+			target := NewMulti[int, string](func(key int) (string, error) {
+				loads++
+				return "reloaded", nil
+			})
+			target.SetCodec(codec)
+			target.SetTTLPolicy(2 * time.Hour)
+			imported, err := target.Import(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, 2, imported, "Expired entries should be skipped")
+			assert.Equal(t, "value-1", target.MustGet(1))
+			assert.Equal(t, "value-2", target.MustGet(2))
+			assert.Equal(t, 0, loads)
+
+			at, ok := target.NextExpiration()
+			assert.True(t, ok)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), at, time.Minute, "Imported values should keep their original expiration")
+		})
+	}
+}
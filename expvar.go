@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"expvar"
+)
+
+// StatsProvider is implemented by caches that report [Stats], such as [MultiCache], [ShardedMultiCache], and [Value].
+type StatsProvider interface {
+	Stats() Stats
+}
+
+var (
+	_ StatsProvider = (*MultiCache[string, string])(nil)
+	_ StatsProvider = (*ShardedMultiCache[string, string])(nil)
+	_ StatsProvider = (*ReadOnlyMultiCache[string, string])(nil)
+	_ StatsProvider = (*MultiCache2[string, string, string])(nil)
+	_ StatsProvider = (*Value[string])(nil)
+)
+
+// PublishExpvar publishes the [Stats] of provider with the expvar package under name, so they're served on /debug/vars with other standard Go service metrics.
+// Stats are read each time the variable is requested, so they're always current.
+//
+// Like expvar.Publish, this function will panic if name is already registered.
+func PublishExpvar(name string, provider StatsProvider) {
+	expvar.Publish(name, expvar.Func(func() any {
+		stats := provider.Stats()
+		return map[string]any{
+			"hits":          stats.Hits,
+			"misses":        stats.Misses,
+			"hit_ratio":     stats.HitRatio(),
+			"loads":         stats.Loads,
+			"load_failures": stats.LoadFailures,
+			"evictions":     stats.Evictions,
+			"stale_served":  stats.StaleServed,
+			"loads_shed":    stats.LoadsShed,
+			"entries":       stats.Entries,
+		}
+	}))
+}
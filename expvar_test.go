@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	PublishExpvar("TestPublishExpvar", mc)
+	mc.MustGet(1)
+	mc.MustGet(1)
+
+	v := expvar.Get("TestPublishExpvar")
+	require.NotNil(t, v)
+	var stats map[string]float64
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &stats))
+	assert.Equal(t, float64(1), stats["hits"])
+	assert.Equal(t, float64(1), stats["misses"])
+	assert.Equal(t, float64(1), stats["loads"])
+	assert.Equal(t, float64(1), stats["entries"])
+	assert.Equal(t, 0.5, stats["hit_ratio"])
+
+	assert.Panics(t, func() {
+		PublishExpvar("TestPublishExpvar", mc)
+	})
+}
+
+func TestValue_Stats(t *testing.T) {
+	fail := true
+	val := New[int](func() (int, error) {
+		if fail {
+			return 0, errors.New("failed")
+		}
+		return 1, nil
+	})
+	_, err := val.Get()
+	assert.Error(t, err)
+	fail = false
+	val.MustGet()
+	val.MustGet()
+
+	assert.Equal(t, Stats{
+		Hits:         1,
+		Misses:       2,
+		Loads:        2,
+		LoadFailures: 1,
+		Entries:      1,
+	}, val.Stats())
+}
@@ -0,0 +1,113 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/saylorsolutions/cache"
+	"io"
+)
+
+// Format encodes and decodes a config file format, so config caches can be created for formats other than [JSON].
+// Adapters for YAML and TOML are provided in their own modules, [github.com/saylorsolutions/cache/file/fileyaml] and [github.com/saylorsolutions/cache/file/filetoml], so their dependencies are only added to applications that use them.
+type Format struct {
+	// Name is the name of the format used in errors, such as "JSON".
+	Name string
+	// Marshal encodes v in the format.
+	// It's used to encode a default set with [WithDefault], and to write values with [Writable.Set].
+	Marshal func(v any) ([]byte, error)
+	// Decode decodes r into v, and returns an error for fields that aren't in v if strict is true.
+	Decode func(r io.Reader, v any, strict bool) error
+}
+
+// JSON is the [Format] used by NewJSONCache.
+var JSON = Format{
+	Name:    "JSON",
+	Marshal: json.Marshal,
+	Decode: func(r io.Reader, v any, strict bool) error {
+		dec := json.NewDecoder(r)
+		if strict {
+			dec.DisallowUnknownFields()
+		}
+		return dec.Decode(v)
+	},
+}
+
+// NewJSONCache returns a cache of the JSON file at path, decoded into a T.
+// Use [WithStrict] to reject fields that aren't in T, and [WithDefault] to fill fields that aren't in the file.
+// See NewReaderCache for more details.
+func NewJSONCache[T any](ctx context.Context, path string, log NotifyLog, opts ...Option) (*cache.Value[T], error) {
+	return NewFormatCache[T](ctx, path, JSON, log, opts...)
+}
+
+// NewWritableJSONCache is the same as NewJSONCache, except that the returned cache may also write a value back to the file as JSON.
+// See NewWritableReaderCache for more details.
+func NewWritableJSONCache[T any](ctx context.Context, path string, log NotifyLog, opts ...Option) (*Writable[T], error) {
+	return NewWritableFormatCache[T](ctx, path, JSON, log, opts...)
+}
+
+// NewFormatCache returns a cache of the file at path, decoded into a T with format.
+// Use [WithStrict] to reject fields that aren't in T, and [WithDefault] to fill fields that aren't in the file.
+// See NewReaderCache for more details.
+//
+// This function will panic if format's Marshal or Decode func is nil.
+func NewFormatCache[T any](ctx context.Context, path string, format Format, log NotifyLog, opts ...Option) (*cache.Value[T], error) {
+	readFunc, err := configReader[T](format, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewReaderCache[T](ctx, path, readFunc, log, opts...)
+}
+
+// NewWritableFormatCache is the same as NewFormatCache, except that the returned cache may also write a value back to the file, encoded with format.
+// See NewWritableReaderCache for more details.
+//
+// This function will panic if format's Marshal or Decode func is nil.
+func NewWritableFormatCache[T any](ctx context.Context, path string, format Format, log NotifyLog, opts ...Option) (*Writable[T], error) {
+	readFunc, err := configReader[T](format, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewWritableReaderCache[T](ctx, path, readFunc, func(w io.Writer, val T) error {
+		data, err := format.Marshal(val)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", format.Name, err)
+		}
+		_, err = w.Write(data)
+		return err
+	}, log, opts...)
+}
+
+// configReader returns a readFunc that decodes a file with format.
+// If a default is set, then it's encoded once here, and decoded into each new value before the file, so fields that aren't in the file keep their default.
+// Decoding the encoded default rather than copying it keeps maps and pointers in the default from being modified by a later load.
+func configReader[T any](format Format, opts []Option) (func(io.Reader) (T, error), error) {
+	if format.Marshal == nil || format.Decode == nil {
+		panic("nil format func")
+	}
+	o := newOptions(opts)
+	fallback, hasFallback, err := defaultValue[T](o)
+	if err != nil {
+		return nil, err
+	}
+	var defaults []byte
+	if hasFallback {
+		defaults, err = format.Marshal(fallback)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode default value as %s: %w", format.Name, err)
+		}
+	}
+	return func(r io.Reader) (T, error) {
+		var t T
+		if defaults != nil {
+			if err := format.Decode(bytes.NewReader(defaults), &t, false); err != nil {
+				return t, fmt.Errorf("failed to decode default value as %s: %w", format.Name, err)
+			}
+		}
+		if err := format.Decode(r, &t, o.strict); err != nil {
+			return t, fmt.Errorf("failed to decode %s: %w", format.Name, err)
+		}
+		return t, nil
+	}, nil
+}
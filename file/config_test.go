@@ -0,0 +1,142 @@
+package file
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type _testConfig struct {
+	Name    string            `json:"name"`
+	Port    int               `json:"port"`
+	Labels  map[string]string `json:"labels"`
+	Enabled bool              `json:"enabled"`
+}
+
+func TestNewConfigCache(t *testing.T) {
+	tests := map[string]struct {
+		newCache func(ctx context.Context, path string, opts ...Option) (func() (_testConfig, error), error)
+		contents string
+		updated  string
+		unknown  string
+	}{
+		"JSON": {
+			newCache: func(ctx context.Context, path string, opts ...Option) (func() (_testConfig, error), error) {
+				val, err := NewJSONCache[_testConfig](ctx, path, nil, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return val.Get, nil
+			},
+			contents: `{"name":"app","labels":{"a":"1"}}`,
+			updated:  `{"name":"updated"}`,
+			unknown:  `{"name":"app","nmae":"typo"}`,
+		},
+		"Writable JSON": {
+			newCache: func(ctx context.Context, path string, opts ...Option) (func() (_testConfig, error), error) {
+				val, err := NewWritableJSONCache[_testConfig](ctx, path, nil, opts...)
+				if err != nil {
+					return nil, err
+				}
+				return val.Get, nil
+			},
+			contents: `{"name":"app","labels":{"a":"1"}}`,
+			updated:  `{"name":"updated"}`,
+			unknown:  `{"name":"app","nmae":"typo"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmp, err := os.MkdirTemp("", "NewConfigCache-*")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmp))
+			}()
+			filename := filepath.Join(tmp, "config")
+			require.NoError(t, os.WriteFile(filename, []byte(tc.contents), 0644))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			defaults := _testConfig{Port: 8080, Labels: map[string]string{"default": "true"}, Enabled: true}
+			get, err := tc.newCache(ctx, filename, WithDefault(defaults), WithStrict())
+			require.NoError(t, err)
+			conf, err := get()
+			require.NoError(t, err)
+			assert.Equal(t, _testConfig{
+				Name:    "app",
+				Port:    8080,
+				Labels:  map[string]string{"default": "true", "a": "1"},
+				Enabled: true,
+			}, conf, "Fields that aren't in the file should keep their default")
+			assert.Equal(t, map[string]string{"default": "true"}, defaults.Labels, "The default should not be modified")
+
+			require.NoError(t, os.WriteFile(filename, []byte(tc.updated), 0644))
+			assert.Eventually(t, func() bool {
+				conf, err := get()
+				return err == nil && conf.Name == "updated"
+			}, time.Second, 10*time.Millisecond, "A changed file should be decoded again")
+			conf, err = get()
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"default": "true"}, conf.Labels, "Defaults should be filled from scratch for each load")
+
+			unknownFile := filepath.Join(tmp, "unknown")
+			require.NoError(t, os.WriteFile(unknownFile, []byte(tc.unknown), 0644))
+			get, err = tc.newCache(ctx, unknownFile)
+			require.NoError(t, err)
+			conf, err = get()
+			assert.NoError(t, err, "Unknown fields should be ignored by default")
+			assert.Equal(t, "app", conf.Name)
+			get, err = tc.newCache(ctx, unknownFile, WithStrict())
+			require.NoError(t, err)
+			_, err = get()
+			assert.Error(t, err, "Unknown fields should be rejected in strict mode")
+		})
+	}
+
+	assert.Panics(t, func() {
+		_, _ = NewFormatCache[_testConfig](context.Background(), "config", Format{Name: "empty"}, nil)
+	})
+}
+
+func TestWithOnReload(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WithOnReload-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "config.json")
+	require.NoError(t, os.WriteFile(filename, []byte(`{"name":"app","port":8080}`), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan [2]_testConfig, 10)
+	val, err := NewJSONCache[_testConfig](ctx, filename, testingLog(t), WithOnReload(func(old, new _testConfig) {
+		reloads <- [2]_testConfig{old, new}
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 8080, val.MustGet().Port)
+
+	// Replacing the file atomically, so a partially written file is never reloaded.
+	temp := filepath.Join(tmp, "config.json.tmp")
+	require.NoError(t, os.WriteFile(temp, []byte(`{"name":"app","port":9090}`), 0644))
+	require.NoError(t, os.Rename(temp, filename))
+	select {
+	case reload := <-reloads:
+		assert.Equal(t, 8080, reload[0].Port)
+		assert.Equal(t, 9090, reload[1].Port)
+	case <-time.After(time.Second):
+		t.Fatal("Reload func should have been called without another Get")
+	}
+	assert.Equal(t, 9090, val.MustGet().Port)
+
+	_, err = NewReaderCache[string](ctx, filename, readString, testingLog(t), WithOnReload(func(old, new int) {}))
+	assert.Error(t, err, "A reload func of the wrong type should be rejected")
+	assert.Panics(t, func() {
+		WithOnReload[int](nil)
+	})
+}
@@ -0,0 +1,175 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/saylorsolutions/cache"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NewDirCache returns a [cache.MultiCache] of the files in dir, keyed by file name.
+// Each file is read with readFunc the first time its name is requested, and a single watcher on dir invalidates each file's value individually when it changes.
+//
+// Keys must be names of files directly within dir, such as "app.json", and requesting a name that includes a path separator will return an error.
+// If a file doesn't exist, then the error returned from Get will match both [ErrFileMissing] and [cache.ErrKeyNotFound], so it may be used with [cache.MultiCache.SetNegativeTTL].
+// If a default is set with [WithDefault], then it's returned for any file that doesn't exist.
+// Unlike NewReaderCache, a file that fails to load won't stop the watcher.
+func NewDirCache[T any](ctx context.Context, dir string, readFunc func(io.Reader) (T, error), log NotifyLog, opts ...Option) (*cache.MultiCache[string, T], error) {
+	return newDirCache[T](ctx, dir, "", readFunc, log, opts)
+}
+
+// NewGlobCache returns a [cache.MultiCache] of the files matching pattern, such as "conf.d/*.yaml", keyed by file name.
+// Matching files are read when the cache is created, and files that are created or changed later are read as soon as they're written, so [cache.MultiCache.Keys] always reflects the matching files.
+// Files that are deleted or renamed are removed from the cache.
+// Errors reading a file after the cache is created are reported to log.
+//
+// Only the file name may contain wildcards, using the syntax of [filepath.Match], and requesting a name that doesn't match will return an error matching [cache.ErrKeyNotFound].
+// If any matching file can't be read when the cache is created, then an error is returned.
+// See NewDirCache for more details.
+func NewGlobCache[T any](ctx context.Context, pattern string, readFunc func(io.Reader) (T, error), log NotifyLog, opts ...Option) (*cache.MultiCache[string, T], error) {
+	dir, glob := filepath.Split(pattern)
+	if dir == "" {
+		dir = "."
+	}
+	if _, err := filepath.Match(glob, ""); err != nil || glob == "" {
+		return nil, fmt.Errorf("invalid glob pattern '%s': %w", pattern, filepath.ErrBadPattern)
+	}
+	if strings.ContainsAny(dir, `*?[`) {
+		return nil, fmt.Errorf("invalid glob pattern '%s': only the file name may contain wildcards", pattern)
+	}
+	return newDirCache[T](ctx, dir, glob, readFunc, log, opts)
+}
+
+// newDirCache creates a cache of the files in dir.
+// If glob is not empty, then only files with names matching glob may be cached, and they're loaded eagerly.
+func newDirCache[T any](ctx context.Context, dir string, glob string, readFunc func(io.Reader) (T, error), log NotifyLog, opts []Option) (*cache.MultiCache[string, T], error) {
+	orig := dir
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for '%s': %w", orig, err)
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat directory '%s': %w", dir, err)
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("'%s' is not a directory", dir)
+	}
+	matches := func(name string) bool {
+		if glob == "" {
+			return true
+		}
+		ok, _ := filepath.Match(glob, name)
+		return ok
+	}
+
+	o := newOptions(opts)
+	fallback, hasFallback, err := defaultValue[T](o)
+	if err != nil {
+		return nil, err
+	}
+	hashes := newContentHashes(o)
+	links := newTargets()
+	_cache := cache.NewMulti[string, T](func(name string) (T, error) {
+		var t T
+		if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+			return t, fmt.Errorf("invalid file name '%s': must be a file directly within '%s'", name, dir)
+		}
+		if !matches(name) {
+			return t, fmt.Errorf("%w: file name '%s' doesn't match '%s'", cache.ErrKeyNotFound, name, glob)
+		}
+		filename := filepath.Join(dir, name)
+		f, err := os.Open(filename)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				if hasFallback {
+					return fallback, nil
+				}
+				return t, fmt.Errorf("%w: %w: %w", cache.ErrKeyNotFound, ErrFileMissing, err)
+			}
+			return t, fmt.Errorf("failed to open file '%s' for reading: %w", filename, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		if fi, err := f.Stat(); err == nil && fi.IsDir() {
+			return t, errors.New("unable to cache directories")
+		}
+		links.track(filename)
+		r, err := hashes.read(filename, f)
+		if err != nil {
+			return t, fmt.Errorf("failed to read file '%s' contents: %w", filename, err)
+		}
+		t, err = readFunc(r)
+		if err != nil {
+			return t, fmt.Errorf("failed to read file '%s' contents: %w", filename, err)
+		}
+		return t, nil
+	})
+
+	if log == nil {
+		log = newNoOpNotifyLog()
+	}
+	// changed handles a change to the file with the given name, which may have been created, removed, or swapped.
+	changed := func(name string) {
+		if glob == "" {
+			_cache.Invalidate(name)
+			return
+		}
+		if !matches(name) {
+			return
+		}
+		// The file is checked directly rather than relying on the event's Op, since an event may combine several operations.
+		if fi, err := os.Stat(filepath.Join(dir, name)); err != nil || fi.IsDir() {
+			_cache.Remove(name)
+			return
+		}
+		_cache.Invalidate(name)
+		if _, err := _cache.Get(name); err != nil {
+			log.Error(err)
+		}
+	}
+	stop, err := watch(ctx, dir, log, o, func(evt fsnotify.Event) {
+		if filepath.Dir(evt.Name) != dir {
+			log.UnrelatedEvent(evt)
+			return
+		}
+		// Files that resolve through a swapped symlink, such as a Kubernetes ConfigMap's "..data" link, have changed even though there's no event for them.
+		for _, filename := range links.swapped() {
+			if filename != evt.Name && hashes.changed(filename) {
+				changed(filepath.Base(filename))
+			}
+		}
+		if !hashes.changed(evt.Name) {
+			return
+		}
+		log.Event(evt)
+		changed(filepath.Base(evt.Name))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if glob != "" {
+		existing, err := filepath.Glob(filepath.Join(dir, glob))
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		for _, filename := range existing {
+			if fi, err := os.Stat(filename); err != nil || fi.IsDir() {
+				continue
+			}
+			if _, err := _cache.Get(filepath.Base(filename)); err != nil {
+				stop()
+				return nil, err
+			}
+		}
+	}
+	return _cache, nil
+}
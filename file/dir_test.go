@@ -0,0 +1,99 @@
+package file
+
+import (
+	"context"
+	"github.com/saylorsolutions/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewDirCache(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "NewDirCache-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "a.txt"), []byte("A"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "b.txt"), []byte("B"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(tmp, "sub"), 0755))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	files, err := NewDirCache[string](ctx, tmp, func(reader io.Reader) (string, error) {
+		data, err := io.ReadAll(reader)
+		return string(data), err
+	}, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, "A", files.MustGet("a.txt"))
+	assert.Equal(t, "B", files.MustGet("b.txt"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "a.txt"), []byte("Updated"), 0644))
+	assert.Eventually(t, func() bool {
+		val, err := files.Get("a.txt")
+		return err == nil && val == "Updated"
+	}, time.Second, 10*time.Millisecond, "A changed file should be invalidated")
+
+	_, err = files.Get("missing.txt")
+	assert.True(t, cache.IsKeyNotFound(err), "A missing file should be reported as not found")
+	assert.ErrorIs(t, err, ErrFileMissing)
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "missing.txt"), []byte("Created"), 0644))
+	assert.Eventually(t, func() bool {
+		val, err := files.Get("missing.txt")
+		return err == nil && val == "Created"
+	}, time.Second, 10*time.Millisecond, "A created file should be loaded")
+
+	_, err = files.Get("../a.txt")
+	assert.Error(t, err)
+	_, err = files.Get("sub")
+	assert.Error(t, err)
+
+	_, err = NewDirCache[string](ctx, filepath.Join(tmp, "a.txt"), nil, nil)
+	assert.Error(t, err, "A file should not be accepted as a directory")
+}
+
+func TestNewGlobCache(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "NewGlobCache-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	confDir := filepath.Join(tmp, "conf.d")
+	require.NoError(t, os.Mkdir(confDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(confDir, "a.yaml"), []byte("A"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(confDir, "notes.txt"), []byte("ignored"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	files, err := NewGlobCache[string](ctx, filepath.Join(confDir, "*.yaml"), func(reader io.Reader) (string, error) {
+		data, err := io.ReadAll(reader)
+		return string(data), err
+	}, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.yaml"}, files.Keys(), "Matching files should be loaded eagerly")
+
+	require.NoError(t, os.WriteFile(filepath.Join(confDir, "b.yaml"), []byte("B"), 0644))
+	assert.Eventually(t, func() bool {
+		val, ok := files.GetIfPresent("b.yaml")
+		return ok && val == "B"
+	}, time.Second, 10*time.Millisecond, "A created file should be added")
+
+	require.NoError(t, os.Remove(filepath.Join(confDir, "a.yaml")))
+	assert.Eventually(t, func() bool {
+		_, ok := files.GetIfPresent("a.yaml")
+		return !ok
+	}, time.Second, 10*time.Millisecond, "A deleted file should be removed")
+	assert.Equal(t, []string{"b.yaml"}, files.Keys())
+
+	_, err = files.Get("notes.txt")
+	assert.True(t, cache.IsKeyNotFound(err), "A file that doesn't match should not be loaded")
+
+	_, err = NewGlobCache[string](ctx, filepath.Join(tmp, "*", "*.yaml"), nil, nil)
+	assert.Error(t, err, "Wildcards should only be allowed in the file name")
+	_, err = NewGlobCache[string](ctx, filepath.Join(confDir, "["), nil, nil)
+	assert.ErrorIs(t, err, filepath.ErrBadPattern)
+}
@@ -3,10 +3,23 @@ Package file provides some easy to use methods for caching file information.
 
 The contents of a file may be cached, with automatic invalidation provided with the [github.com/fsnotify/fsnotify] library.
 The actual file bytes may be used with [NewFileCache] or [NewEagerFileCache].
+A file doesn't need to exist when its cache is created, and Get returns [ErrFileMissing], or the value set with [WithDefault], until it's created.
 
 Alternatively, reading the file's contents and decoding it can be combined into a single function with [NewReaderCache].
 The Value returned will store the decoded form for easy retrieval.
+Files in an [io/fs.FS], such as an embed.FS or a zip archive, may be cached with [NewReaderCacheFS], which is invalidated manually or by a [Notifier].
+Config files may be decoded directly with [NewJSONCache], or with [NewFormatCache] for other formats, which support [WithStrict] and default values set with [WithDefault].
+YAML and TOML formats are provided by the fileyaml and filetoml modules, so their dependencies are only added to applications that use them.
+Applications that own their config file may use [NewWritableFileCache], [NewWritableReaderCache], or a writable config cache like [NewWritableJSONCache], which write new values to the file atomically with [Writable.Set].
+Every file in a directory may be cached in a single MultiCache keyed by file name with [NewDirCache], which uses one watcher for the whole directory.
+Files matching a pattern such as "conf.d/*.yaml" may be cached with [NewGlobCache], which adds and removes entries as matching files are created and deleted.
+
+Each constructor accepts [Option] values to configure how changes are detected, such as [WithDebounce] to combine the burst of events produced by a single save.
+Filesystems that don't support notifications reliably, such as NFS, may be watched with [WithPolling] instead.
+To avoid reloading a file when it's touched or rewritten with the same contents, use [WithContentHash].
+Files replaced by an atomic save, or through a swapped symlink like a Kubernetes ConfigMap mount, continue to be reloaded after each update.
 
 If you need to perform some action in response to the file being changed, then use OnInvalidate on the Value returned from any of these functions.
+To compare the previous and new values each time a file is reloaded, use [WithOnReload].
 */
 package file
@@ -2,23 +2,25 @@ package file
 
 import (
 	"context"
+	"errors"
 	"github.com/saylorsolutions/cache"
 	"io"
 )
 
 // NewFileCache creates a new [cache.Value] that reads the given file in its loader.
-func NewFileCache(ctx context.Context, filename string, log NotifyLog) (*cache.Value[[]byte], error) {
-	return NewReaderCache[[]byte](ctx, filename, io.ReadAll, log)
+func NewFileCache(ctx context.Context, filename string, log NotifyLog, opts ...Option) (*cache.Value[[]byte], error) {
+	return NewReaderCache[[]byte](ctx, filename, io.ReadAll, log, opts...)
 }
 
 // NewEagerFileCache is the same as NewFileCache, except that it will proactively read the file's contents into memory.
-func NewEagerFileCache(ctx context.Context, filename string, log NotifyLog) (*cache.Value[[]byte], error) {
-	fileCache, err := NewFileCache(ctx, filename, log)
+// A file that doesn't exist yet isn't an error, and it will be read once it's created.
+func NewEagerFileCache(ctx context.Context, filename string, log NotifyLog, opts ...Option) (*cache.Value[[]byte], error) {
+	fileCache, err := NewFileCache(ctx, filename, log, opts...)
 	if err != nil {
 		return nil, err
 	}
 	_, err = fileCache.Get()
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrFileMissing) {
 		return nil, err
 	}
 	return fileCache, nil
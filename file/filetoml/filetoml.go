@@ -0,0 +1,53 @@
+// Package filetoml provides config caches of TOML files, using [github.com/BurntSushi/toml].
+//
+// This package is its own module, so the TOML library isn't added to the dependencies of applications that only import [github.com/saylorsolutions/cache/file].
+package filetoml
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/saylorsolutions/cache"
+	"github.com/saylorsolutions/cache/file"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format is the [file.Format] for TOML files.
+var Format = file.Format{
+	Name: "TOML",
+	Marshal: func(v any) ([]byte, error) {
+		var buf bytes.Buffer
+		err := toml.NewEncoder(&buf).Encode(v)
+		return buf.Bytes(), err
+	},
+	Decode: func(r io.Reader, v any, strict bool) error {
+		md, err := toml.NewDecoder(r).Decode(v)
+		if err != nil {
+			return err
+		}
+		if undecoded := md.Undecoded(); strict && len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, key := range undecoded {
+				keys[i] = key.String()
+			}
+			sort.Strings(keys)
+			return fmt.Errorf("unknown keys: %s", strings.Join(keys, ", "))
+		}
+		return nil
+	},
+}
+
+// NewCache returns a cache of the TOML file at path, decoded into a T.
+// See [file.NewFormatCache] for more details.
+func NewCache[T any](ctx context.Context, path string, log file.NotifyLog, opts ...file.Option) (*cache.Value[T], error) {
+	return file.NewFormatCache[T](ctx, path, Format, log, opts...)
+}
+
+// NewWritableCache is the same as NewCache, except that the returned cache may also write a value back to the file as TOML.
+// See [file.NewWritableFormatCache] for more details.
+func NewWritableCache[T any](ctx context.Context, path string, log file.NotifyLog, opts ...file.Option) (*file.Writable[T], error) {
+	return file.NewWritableFormatCache[T](ctx, path, Format, log, opts...)
+}
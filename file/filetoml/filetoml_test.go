@@ -0,0 +1,80 @@
+package filetoml
+
+import (
+	"context"
+	"github.com/saylorsolutions/cache/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type _testConfig struct {
+	Name    string            `toml:"name"`
+	Port    int               `toml:"port"`
+	Labels  map[string]string `toml:"labels"`
+	Enabled bool              `toml:"enabled"`
+}
+
+func TestNewCache(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "filetoml-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "config")
+	require.NoError(t, os.WriteFile(filename, []byte("name = \"app\"\n[labels]\na = \"1\"\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defaults := _testConfig{Port: 8080, Labels: map[string]string{"default": "true"}, Enabled: true}
+	val, err := NewCache[_testConfig](ctx, filename, nil, file.WithDefault(defaults), file.WithStrict())
+	require.NoError(t, err)
+	assert.Equal(t, _testConfig{
+		Name:    "app",
+		Port:    8080,
+		Labels:  map[string]string{"default": "true", "a": "1"},
+		Enabled: true,
+	}, val.MustGet(), "Fields that aren't in the file should keep their default")
+	assert.Equal(t, map[string]string{"default": "true"}, defaults.Labels, "The default should not be modified")
+
+	require.NoError(t, os.WriteFile(filename, []byte("name = \"updated\"\n"), 0644))
+	assert.Eventually(t, func() bool {
+		conf, err := val.Get()
+		return err == nil && conf.Name == "updated"
+	}, time.Second, 10*time.Millisecond, "A changed file should be decoded again")
+
+	unknownFile := filepath.Join(tmp, "unknown")
+	require.NoError(t, os.WriteFile(unknownFile, []byte("name = \"app\"\nnmae = \"typo\"\n"), 0644))
+	lenient, err := NewCache[_testConfig](ctx, unknownFile, nil)
+	require.NoError(t, err)
+	conf, err := lenient.Get()
+	assert.NoError(t, err, "Unknown fields should be ignored by default")
+	assert.Equal(t, "app", conf.Name)
+	strict, err := NewCache[_testConfig](ctx, unknownFile, nil, file.WithStrict())
+	require.NoError(t, err)
+	_, err = strict.Get()
+	assert.Error(t, err, "Unknown fields should be rejected in strict mode")
+}
+
+func TestNewWritableCache(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "filetoml-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "config")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewWritableCache[_testConfig](ctx, filename, nil, file.WithDefault(_testConfig{Port: 8080}))
+	require.NoError(t, err)
+	assert.Equal(t, 8080, val.MustGet().Port)
+
+	require.NoError(t, val.Set(_testConfig{Name: "app", Port: 9090}))
+	conf := val.MustGet()
+	assert.Equal(t, "app", conf.Name, "A written value should be read back")
+	assert.Equal(t, 9090, conf.Port)
+}
@@ -0,0 +1,19 @@
+module github.com/saylorsolutions/cache/file/filetoml
+
+go 1.20
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/saylorsolutions/cache v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsnotify/fsnotify v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/saylorsolutions/cache => ../../
@@ -0,0 +1,40 @@
+// Package fileyaml provides config caches of YAML files, using [gopkg.in/yaml.v3].
+//
+// This package is its own module, so the YAML library isn't added to the dependencies of applications that only import [github.com/saylorsolutions/cache/file].
+package fileyaml
+
+import (
+	"context"
+	"errors"
+	"github.com/saylorsolutions/cache"
+	"github.com/saylorsolutions/cache/file"
+	"gopkg.in/yaml.v3"
+	"io"
+)
+
+// Format is the [file.Format] for YAML files.
+// An empty file is decoded as an empty document.
+var Format = file.Format{
+	Name:    "YAML",
+	Marshal: yaml.Marshal,
+	Decode: func(r io.Reader, v any, strict bool) error {
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(strict)
+		if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	},
+}
+
+// NewCache returns a cache of the YAML file at path, decoded into a T.
+// See [file.NewFormatCache] for more details.
+func NewCache[T any](ctx context.Context, path string, log file.NotifyLog, opts ...file.Option) (*cache.Value[T], error) {
+	return file.NewFormatCache[T](ctx, path, Format, log, opts...)
+}
+
+// NewWritableCache is the same as NewCache, except that the returned cache may also write a value back to the file as YAML.
+// See [file.NewWritableFormatCache] for more details.
+func NewWritableCache[T any](ctx context.Context, path string, log file.NotifyLog, opts ...file.Option) (*file.Writable[T], error) {
+	return file.NewWritableFormatCache[T](ctx, path, Format, log, opts...)
+}
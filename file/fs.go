@@ -0,0 +1,180 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/saylorsolutions/cache"
+	"io"
+	"io/fs"
+	"time"
+)
+
+// Notifier reports changes to files in an [fs.FS], since an fs.FS has no way to watch for changes itself.
+type Notifier interface {
+	// Notify starts watching for changes, and calls changed with the name of each file that changes until ctx is done.
+	// Names are paths within the fs.FS, as accepted by [fs.ValidPath].
+	// Notify should return once watching has started, and an error returned from Notify is returned from the cache's constructor.
+	Notify(ctx context.Context, changed func(name string)) error
+}
+
+// NotifierFunc is a func that implements [Notifier].
+type NotifierFunc func(ctx context.Context, changed func(name string)) error
+
+func (f NotifierFunc) Notify(ctx context.Context, changed func(name string)) error {
+	return f(ctx, changed)
+}
+
+// WithNotifier sets the [Notifier] used by [NewReaderCacheFS] to detect changes.
+// Other caches aren't affected.
+//
+// This function will panic if notifier is nil.
+func WithNotifier(notifier Notifier) Option {
+	if notifier == nil {
+		panic("nil notifier")
+	}
+	return func(opts *options) {
+		opts.notifier = notifier
+	}
+}
+
+// NewReaderCacheFS is the same as NewReaderCache, except that the file is read from fsys, such as an [embed.FS], a zip archive, or an [fstest.MapFS].
+// Since an fs.FS can't be watched, the cache is only invalidated when Invalidate is called on it, unless changes are reported by a [Notifier] set with [WithNotifier].
+// Alternatively, [WithPolling] will check the file in fsys for changes every interval.
+//
+// Unlike NewReaderCache, an error reading the file won't stop the cache from being invalidated.
+func NewReaderCacheFS[T any](ctx context.Context, fsys fs.FS, name string, readFunc func(io.Reader) (T, error), log NotifyLog, opts ...Option) (*cache.Value[T], error) {
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("invalid file name '%s': %w", name, fs.ErrInvalid)
+	}
+	fi, err := fs.Stat(fsys, name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// The file will be loaded once it's created.
+	case err != nil:
+		return nil, fmt.Errorf("unable to stat file '%s': %w", name, err)
+	case fi.IsDir():
+		return nil, errors.New("unable to cache directories")
+	}
+
+	o := newOptions(opts)
+	fallback, hasFallback, err := defaultValue[T](o)
+	if err != nil {
+		return nil, err
+	}
+	reload, err := newReloads[T](o)
+	if err != nil {
+		return nil, err
+	}
+	hashes := newContentHashes(o)
+	if hashes != nil {
+		hashes.readFile = func(filename string) ([]byte, error) {
+			return fs.ReadFile(fsys, filename)
+		}
+	}
+	_cache := cache.New(func() (T, error) {
+		var t T
+		f, err := fsys.Open(name)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				if hasFallback {
+					reload.record(fallback)
+					return fallback, nil
+				}
+				return t, fmt.Errorf("%w: %w", ErrFileMissing, err)
+			}
+			return t, fmt.Errorf("failed to open file '%s' for reading: %w", name, err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		r, err := hashes.read(name, f)
+		if err != nil {
+			return t, fmt.Errorf("failed to read file '%s' contents: %w", name, err)
+		}
+		t, err = readFunc(r)
+		if err != nil {
+			return t, fmt.Errorf("failed to read file '%s' contents: %w", name, err)
+		}
+		reload.record(t)
+		return t, nil
+	})
+
+	if log == nil {
+		log = newNoOpNotifyLog()
+	}
+	notifier := o.notifier
+	if notifier == nil && o.poll > 0 {
+		notifier = &fsPoller{fsys: fsys, name: name, interval: o.poll}
+	}
+	if notifier == nil {
+		return _cache, nil
+	}
+	onEvent := func(evt fsnotify.Event) {
+		if evt.Name != name {
+			log.UnrelatedEvent(evt)
+			return
+		}
+		if !hashes.changed(name) {
+			return
+		}
+		log.Event(evt)
+		reload.invalidate(_cache, log)
+	}
+	if o.debounce > 0 {
+		onEvent = debounce(ctx, o.debounce, onEvent)
+	}
+	err = notifier.Notify(ctx, func(changed string) {
+		if ctx.Err() != nil {
+			return
+		}
+		// A Notifier only reports names, so each change is logged as a write.
+		onEvent(fsnotify.Event{Name: changed, Op: fsnotify.Write})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start notifier: %w", err)
+	}
+	return _cache, nil
+}
+
+// fsPoller is a [Notifier] that checks a single file in an fs.FS for changes every interval.
+// A file is considered changed when it's created or removed, or its size, modification time, or mode changes.
+type fsPoller struct {
+	fsys     fs.FS
+	name     string
+	interval time.Duration
+}
+
+func (p *fsPoller) Notify(ctx context.Context, changed func(name string)) error {
+	state, exists := p.stat()
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			next, nextExists := p.stat()
+			if nextExists != exists || next.size != state.size || !next.modTime.Equal(state.modTime) || next.mode != state.mode {
+				changed(p.name)
+			}
+			state, exists = next, nextExists
+		}
+	}()
+	return nil
+}
+
+func (p *fsPoller) stat() (polledFile, bool) {
+	fi, err := fs.Stat(p.fsys, p.name)
+	if err != nil {
+		return polledFile{}, false
+	}
+	return polledFile{
+		size:    fi.Size(),
+		modTime: fi.ModTime(),
+		mode:    fi.Mode(),
+	}, true
+}
@@ -0,0 +1,90 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestNewReaderCacheFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/app.txt": &fstest.MapFile{Data: []byte("A")},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewReaderCacheFS[string](ctx, fsys, "conf/app.txt", readString, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, "A", val.MustGet())
+
+	fsys["conf/app.txt"] = &fstest.MapFile{Data: []byte("B")}
+	assert.Equal(t, "A", val.MustGet(), "Changes should not be detected without a notifier")
+	val.Invalidate()
+	assert.Equal(t, "B", val.MustGet(), "Manual invalidation should reload the file")
+
+	missing, err := NewReaderCacheFS[string](ctx, fsys, "conf/missing.txt", readString, testingLog(t))
+	require.NoError(t, err)
+	_, err = missing.Get()
+	assert.ErrorIs(t, err, ErrFileMissing)
+
+	_, err = NewReaderCacheFS[string](ctx, fsys, "conf", readString, testingLog(t))
+	assert.Error(t, err, "A directory should not be accepted")
+	_, err = NewReaderCacheFS[string](ctx, fsys, "../app.txt", readString, testingLog(t))
+	assert.Error(t, err, "An invalid path should not be accepted")
+}
+
+func TestWithNotifier(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.txt": &fstest.MapFile{Data: []byte("A")},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var notify func(name string)
+	val, err := NewReaderCacheFS[string](ctx, fsys, "app.txt", readString, testingLog(t), WithNotifier(NotifierFunc(func(ctx context.Context, changed func(name string)) error {
+		notify = changed
+		return nil
+	})))
+	require.NoError(t, err)
+	require.NotNil(t, notify)
+	assert.Equal(t, "A", val.MustGet())
+
+	fsys["app.txt"] = &fstest.MapFile{Data: []byte("B")}
+	notify("other.txt")
+	assert.Equal(t, "A", val.MustGet(), "Changes to other files should be ignored")
+	notify("app.txt")
+	assert.Equal(t, "B", val.MustGet(), "A notified change should invalidate the value")
+
+	_, err = NewReaderCacheFS[string](ctx, fsys, "app.txt", readString, testingLog(t), WithNotifier(NotifierFunc(func(ctx context.Context, changed func(name string)) error {
+		return errors.New("unable to watch")
+	})))
+	assert.Error(t, err, "An error starting the notifier should be returned")
+	assert.Panics(t, func() {
+		WithNotifier(nil)
+	})
+}
+
+func TestNewReaderCacheFS_Polling(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "NewReaderCacheFS-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "app.txt"), []byte("A"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewReaderCacheFS[string](ctx, os.DirFS(tmp), "app.txt", readString, testingLog(t), WithPolling(20*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, "A", val.MustGet())
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "app.txt"), []byte("Changed"), 0644))
+	assert.Eventually(t, func() bool {
+		data, err := val.Get()
+		return err == nil && data == "Changed"
+	}, time.Second, 10*time.Millisecond, "A changed file should be detected by polling")
+}
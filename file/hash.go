@@ -0,0 +1,57 @@
+package file
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sync"
+)
+
+// contentHashes records a checksum of each file's contents when it's loaded, so events that don't change a file's contents can be ignored.
+// A nil *contentHashes is valid, and treats every event as a change.
+type contentHashes struct {
+	mux  sync.Mutex
+	sums map[string][sha256.Size]byte
+	// readFile reads a file to compare its checksum, which is os.ReadFile unless the files are in an fs.FS.
+	readFile func(filename string) ([]byte, error)
+}
+
+func newContentHashes(opts options) *contentHashes {
+	if !opts.contentHash {
+		return nil
+	}
+	return &contentHashes{sums: map[string][sha256.Size]byte{}, readFile: os.ReadFile}
+}
+
+// read returns a reader of the contents of filename from r, and records their checksum.
+func (h *contentHashes) read(filename string, r io.Reader) (io.Reader, error) {
+	if h == nil {
+		return r, nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.sums[filename] = sha256.Sum256(data)
+	return bytes.NewReader(data), nil
+}
+
+// changed returns true if the contents of filename differ from when it was last read.
+// If the file can't be read, or hasn't been read before, then it's considered changed.
+func (h *contentHashes) changed(filename string) bool {
+	if h == nil {
+		return true
+	}
+	data, err := h.readFile(filename)
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	prev, ok := h.sums[filename]
+	if err != nil || !ok {
+		delete(h.sums, filename)
+		return true
+	}
+	return sha256.Sum256(data) != prev
+}
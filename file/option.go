@@ -0,0 +1,140 @@
+package file
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures how a file cache watches for changes.
+// Options may be passed to any of the constructors in this package.
+type Option func(opts *options)
+
+type options struct {
+	debounce time.Duration
+	poll     time.Duration
+	// contentHash is true if events should be ignored when they don't change a file's contents.
+	contentHash bool
+	// hasDefault is true if defaultVal should be returned for a missing file instead of ErrFileMissing.
+	hasDefault bool
+	defaultVal any
+	// onReload is a func(old, new T) that's called after a single file cache is reloaded, or nil.
+	onReload any
+	// notifier reports changes for caches of an fs.FS, or is nil.
+	notifier Notifier
+	// strict is true if config caches should reject fields that aren't in the decoded type.
+	strict bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithDebounce waits until a file's events have settled for window before invalidating its cached value.
+// Editors and atomic writers often produce a burst of events for a single save, and without a debounce window each event causes another invalidation and reload.
+// Events for a file within window of each other are combined, so a save settles into a single reload.
+//
+// This function will panic if window <= 0.
+func WithDebounce(window time.Duration) Option {
+	if window <= 0 {
+		panic("debounce window <= 0")
+	}
+	return func(opts *options) {
+		opts.debounce = window
+	}
+}
+
+// WithPolling checks for changes by reading the watched directory every interval, rather than relying on filesystem notifications.
+// fsnotify doesn't fire reliably on NFS, some container mounts, and certain network filesystems, so polling may be used there instead.
+// A file is considered changed when its size, modification time, or mode changes, and the same events are logged as with filesystem notifications.
+//
+// This function will panic if interval <= 0.
+func WithPolling(interval time.Duration) Option {
+	if interval <= 0 {
+		panic("polling interval <= 0")
+	}
+	return func(opts *options) {
+		opts.poll = interval
+	}
+}
+
+// WithContentHash records a checksum of a file's contents each time it's read, and ignores events that don't change its contents.
+// This keeps a touch, chmod, or rewrite with the same contents from invalidating the cached value, and calling its OnInvalidate handlers.
+// Each file is read once more for every event to compute its checksum, and the checksum is compared before any other handling.
+func WithContentHash() Option {
+	return func(opts *options) {
+		opts.contentHash = true
+	}
+}
+
+// WithDefault returns val from Get while the watched file doesn't exist, rather than an error matching [ErrFileMissing].
+// The file is loaded as usual once it's created.
+// Config caches like [NewJSONCache] also start decoding from val, so fields that aren't in the file keep their value from val.
+// The type of val must match the type of the cache it's passed to, or the constructor will return an error.
+func WithDefault[T any](val T) Option {
+	return func(opts *options) {
+		opts.hasDefault = true
+		opts.defaultVal = val
+	}
+}
+
+// WithOnReload calls fn with the previous and new values each time a cache of a single file, such as [NewReaderCache] or [NewJSONCache], is reloaded after the file changes.
+// This allows an application to diff its configuration and apply only the parts that changed, such as rebinding a listener only if the port changed.
+//
+// With this option, the file is reloaded as soon as a change is detected, rather than on the next call to Get.
+// fn isn't called for the first load, or when a reload fails, and errors from a reload are reported to the cache's [NotifyLog].
+// Calls to fn are serialized, including reloads caused by [Writable.Set], and fn may call Get on the cache, but must not call Set.
+// The type of fn must match the type of the cache it's passed to, or the constructor will return an error.
+//
+// This function will panic if fn is nil.
+func WithOnReload[T any](fn func(old, new T)) Option {
+	if fn == nil {
+		panic("nil reload func")
+	}
+	return func(opts *options) {
+		opts.onReload = fn
+	}
+}
+
+// WithStrict causes config caches like [NewJSONCache] to return an error when the file contains fields that aren't in the decoded type.
+// This catches misspelled or outdated config keys, which would otherwise be silently ignored.
+// Other caches aren't affected.
+func WithStrict() Option {
+	return func(opts *options) {
+		opts.strict = true
+	}
+}
+
+// defaultValue returns the value set with WithDefault, if any.
+// An error is returned if the value set can't be used as a T.
+func defaultValue[T any](opts options) (val T, ok bool, err error) {
+	if !opts.hasDefault {
+		return val, false, nil
+	}
+	if opts.defaultVal == nil {
+		// A nil interface value can't be asserted, but it's still a valid default.
+		return val, true, nil
+	}
+	val, ok = opts.defaultVal.(T)
+	if !ok {
+		return val, false, fmt.Errorf("default value of type %T can't be used for a cache of %T", opts.defaultVal, val)
+	}
+	return val, true, nil
+}
+
+// reloadFunc returns the func set with WithOnReload, or nil if it isn't set.
+// An error is returned if the func set doesn't accept a T.
+func reloadFunc[T any](opts options) (func(old, new T), error) {
+	if opts.onReload == nil {
+		return nil, nil
+	}
+	fn, ok := opts.onReload.(func(old, new T))
+	if !ok {
+		var t T
+		return nil, fmt.Errorf("reload func of type %T can't be used for a cache of %T", opts.onReload, t)
+	}
+	return fn, nil
+}
@@ -7,87 +7,108 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/saylorsolutions/cache"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
 
+// ErrFileMissing is returned from a file cache when the watched file doesn't exist.
+// Use [WithDefault] to return a default value instead.
+var ErrFileMissing = errors.New("file doesn't exist")
+
 // NewReaderCache returns a cache of a type extracted from the watched file.
 // Whatever type is produced from readFunc will be the type of the [cache.Value], which makes this useful for unmarshalling a file's contents into a user defined type.
-func NewReaderCache[T any](ctx context.Context, filename string, readFunc func(io.Reader) (T, error), log NotifyLog) (*cache.Value[T], error) {
+// The file doesn't need to exist when the cache is created, but its directory does.
+// Until the file is created, Get will return an error matching [ErrFileMissing], and the file will be loaded once it's created.
+func NewReaderCache[T any](ctx context.Context, filename string, readFunc func(io.Reader) (T, error), log NotifyLog, opts ...Option) (*cache.Value[T], error) {
+	_cache, _, err := newReaderCache[T](ctx, filename, readFunc, log, opts)
+	return _cache, err
+}
+
+// newReaderCache creates the cache returned from NewReaderCache, and also returns the reloads used to invalidate it.
+func newReaderCache[T any](ctx context.Context, filename string, readFunc func(io.Reader) (T, error), log NotifyLog, opts []Option) (*cache.Value[T], *reloads[T], error) {
 	orig := filename
 	filename, err := filepath.Abs(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get absolute path for '%s': %w", orig, err)
+		return nil, nil, fmt.Errorf("failed to get absolute path for '%s': %w", orig, err)
 	}
 	fi, err := os.Stat(filename)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// The file will be loaded once it's created.
+	case err != nil:
+		return nil, nil, fmt.Errorf("unable to stat file '%s': %w", filename, err)
+	case fi.IsDir():
+		return nil, nil, errors.New("unable to cache directories")
+	}
+
+	o := newOptions(opts)
+	fallback, hasFallback, err := defaultValue[T](o)
 	if err != nil {
-		return nil, fmt.Errorf("unable to stat file '%s': %w", filename, err)
+		return nil, nil, err
 	}
-	if fi.IsDir() {
-		return nil, errors.New("unable to cache directories")
+	reload, err := newReloads[T](o)
+	if err != nil {
+		return nil, nil, err
 	}
-
+	hashes := newContentHashes(o)
+	links := newTargets()
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 	loader := cache.LoaderFunc[T](func() (T, error) {
 		var t T
 		f, err := os.Open(filename)
 		if err != nil {
+			// A missing file may not have been created yet, or may be replaced by an atomic save, so the watcher is kept running to load it once it's created.
+			if errors.Is(err, fs.ErrNotExist) {
+				if hasFallback {
+					reload.record(fallback)
+					return fallback, nil
+				}
+				return t, fmt.Errorf("%w: %w", ErrFileMissing, err)
+			}
 			cancel()
 			return t, fmt.Errorf("failed to open file '%s' for reading: %w", filename, err)
 		}
 		defer func() {
 			_ = f.Close()
 		}()
+		links.track(filename)
 
-		t, err = readFunc(f)
+		r, err := hashes.read(filename, f)
 		if err != nil {
 			cancel()
 			return t, fmt.Errorf("failed to read file '%s' contents: %w", filename, err)
 		}
+		t, err = readFunc(r)
+		if err != nil {
+			cancel()
+			return t, fmt.Errorf("failed to read file '%s' contents: %w", filename, err)
+		}
+		reload.record(t)
 		return t, nil
 	})
 	_cache := cache.New(loader)
 
-	watchDir := filepath.Dir(filename)
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, fmt.Errorf("unable to create filesystem watcher: %w", err)
-	}
-
 	if log == nil {
 		log = newNoOpNotifyLog()
 	}
-
-	go func() {
-		defer func() {
-			err := watcher.Close()
-			if err != nil {
-				log.Error(fmt.Errorf("failed to close watcher for goroutine exit: %w", err))
-			}
-		}()
-		err := watcher.Add(watchDir)
-		if err != nil {
-			log.Error(fmt.Errorf("failed to add file '%s' to watcher: %w", filename, err))
+	_, err = watch(ctx, filepath.Dir(filename), log, o, func(evt fsnotify.Event) {
+		if evt.Name != filename && len(links.swapped()) == 0 {
+			log.UnrelatedEvent(evt)
 			return
 		}
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case evt := <-watcher.Events:
-				if evt.Name != filename {
-					log.UnrelatedEvent(evt)
-					continue
-				}
-				log.Event(evt)
-				// Any change could indicate the need for a reload.
-				// The loader will cancel the context if there's a hard stop error, so we don't need to handle the various Op cases here.
-				_cache.Invalidate()
-			case err := <-watcher.Errors:
-				log.Error(fmt.Errorf("error watching cache file '%s': %w", filename, err))
-			}
+		if !hashes.changed(filename) {
+			return
 		}
-	}()
-	return _cache, nil
+		log.Event(evt)
+		// Any change could indicate the need for a reload, including a remove or rename, since the file may be replaced by an atomic save.
+		// The loader will cancel the context if there's a hard stop error, so we don't need to handle the various Op cases here.
+		reload.invalidate(_cache, log)
+	})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return _cache, reload, nil
 }
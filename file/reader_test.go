@@ -121,3 +121,38 @@ func _mkTmp() (string, func()) {
 		log.Println("Removed temp dir, err:", err)
 	}
 }
+
+func TestNewReaderCache_Missing(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "NewReaderCacheMissing-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewEagerFileCache(ctx, filename, testingLog(t))
+	require.NoError(t, err, "A missing file should not fail construction")
+	_, err = val.Get()
+	assert.ErrorIs(t, err, ErrFileMissing)
+
+	withDefault, err := NewReaderCache[string](ctx, filename, readString, testingLog(t), WithDefault("default"))
+	require.NoError(t, err)
+	assert.Equal(t, "default", withDefault.MustGet())
+
+	require.NoError(t, os.WriteFile(filename, []byte("Created"), 0644))
+	assert.Eventually(t, func() bool {
+		data, err := val.Get()
+		return err == nil && string(data) == "Created"
+	}, time.Second, 10*time.Millisecond, "A created file should be loaded")
+	assert.Eventually(t, func() bool {
+		data, err := withDefault.Get()
+		return err == nil && data == "Created"
+	}, time.Second, 10*time.Millisecond, "A created file should replace the default")
+
+	_, err = NewReaderCache[string](ctx, filename, readString, testingLog(t), WithDefault(5))
+	assert.Error(t, err, "A default of the wrong type should be rejected")
+	_, err = NewReaderCache[string](ctx, filepath.Join(tmp, "missing", "test.txt"), readString, testingLog(t))
+	assert.Error(t, err, "The file's directory must exist")
+}
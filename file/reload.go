@@ -0,0 +1,61 @@
+package file
+
+import (
+	"errors"
+	"github.com/saylorsolutions/cache"
+	"sync"
+)
+
+// reloads invalidates a single file cache when its file changes, and passes the old and new values to the func set with WithOnReload.
+type reloads[T any] struct {
+	onReload func(old, new T)
+	// dispatch serializes invalidate, so reloads from the watcher and from Writable.Set don't overlap, and each captures the value loaded by the one before it.
+	// It's separate from mux, since the loader calls record while invalidate holds dispatch.
+	dispatch sync.Mutex
+	mux      sync.Mutex
+	// last is the most recently loaded value, which is passed to onReload as the old value.
+	last   T
+	loaded bool
+}
+
+func newReloads[T any](opts options) (*reloads[T], error) {
+	onReload, err := reloadFunc[T](opts)
+	if err != nil {
+		return nil, err
+	}
+	return &reloads[T]{onReload: onReload}, nil
+}
+
+// record is called by the loader with each value it loads.
+func (r *reloads[T]) record(t T) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.last, r.loaded = t, true
+}
+
+// invalidate invalidates c after its file changed.
+// If a reload func is set, then c is reloaded immediately, and the reload func is called with the old and new values.
+func (r *reloads[T]) invalidate(c *cache.Value[T], log NotifyLog) {
+	r.dispatch.Lock()
+	defer r.dispatch.Unlock()
+	if r.onReload == nil {
+		c.Invalidate()
+		return
+	}
+	// The old value is captured before invalidating, since a concurrent Get may load the new value as soon as it's invalidated.
+	r.mux.Lock()
+	old, ok := r.last, r.loaded
+	r.mux.Unlock()
+	c.Invalidate()
+	t, err := c.Get()
+	if err != nil {
+		// A missing file is expected during an atomic save, and it will be reloaded once it's created.
+		if !errors.Is(err, ErrFileMissing) {
+			log.Error(err)
+		}
+		return
+	}
+	if ok {
+		r.onReload(old, t)
+	}
+}
@@ -0,0 +1,45 @@
+package file
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// targets tracks the resolved path of each loaded file.
+// Kubernetes ConfigMaps and some editors update a file by swapping a symlink in its path, which doesn't produce an event for the file itself.
+// Checking whether a file's resolved path has changed allows it to be invalidated after such a swap.
+type targets struct {
+	mux      sync.Mutex
+	resolved map[string]string
+}
+
+func newTargets() *targets {
+	return &targets{resolved: map[string]string{}}
+}
+
+// track records the resolved path of filename after it's loaded.
+func (t *targets) track(filename string) {
+	resolved, err := filepath.EvalSymlinks(filename)
+	if err != nil {
+		return
+	}
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.resolved[filename] = resolved
+}
+
+// swapped returns each tracked file that resolves to a different path than when it was loaded, or that no longer resolves.
+// Returned files are no longer tracked until they're loaded again.
+func (t *targets) swapped() []string {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	var swapped []string
+	for filename, prev := range t.resolved {
+		resolved, err := filepath.EvalSymlinks(filename)
+		if err != nil || resolved != prev {
+			swapped = append(swapped, filename)
+			delete(t.resolved, filename)
+		}
+	}
+	return swapped
+}
@@ -0,0 +1,106 @@
+package file
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readString(reader io.Reader) (string, error) {
+	data, err := io.ReadAll(reader)
+	return string(data), err
+}
+
+func TestNewReaderCache_AtomicSave(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "AtomicSave-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("0"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewReaderCache[string](ctx, filename, readString, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, "0", val.MustGet())
+
+	// Editors commonly write a temp file and rename it over the original.
+	for _, data := range []string{"1", "2"} {
+		temp := filepath.Join(tmp, ".test.txt.swp")
+		require.NoError(t, os.WriteFile(temp, []byte(data), 0644))
+		require.NoError(t, os.Rename(temp, filename))
+		assert.Eventually(t, func() bool {
+			got, err := val.Get()
+			return err == nil && got == data
+		}, time.Second, 10*time.Millisecond, "A file replaced by a rename should be reloaded")
+	}
+
+	// Removing and recreating the file shouldn't stop the watcher, even if it's read while missing.
+	require.NoError(t, os.Remove(filename))
+	assert.Eventually(t, func() bool {
+		_, err := val.Get()
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, os.WriteFile(filename, []byte("3"), 0644))
+	assert.Eventually(t, func() bool {
+		got, err := val.Get()
+		return err == nil && got == "3"
+	}, time.Second, 10*time.Millisecond, "A recreated file should be reloaded")
+}
+
+// mkConfigMap lays out dir the way Kubernetes mounts a ConfigMap, where each file is a symlink through a "..data" symlink to a timestamped directory.
+// Calling the returned function atomically swaps "..data" to a new directory with the given contents.
+func mkConfigMap(t *testing.T, dir string, name string) func(contents string) {
+	gen := 0
+	swap := func(contents string) {
+		gen++
+		target := filepath.Join(dir, "..gen"+string(rune('0'+gen)))
+		require.NoError(t, os.Mkdir(target, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(target, name), []byte(contents), 0644))
+		tmpLink := filepath.Join(dir, "..data_tmp")
+		require.NoError(t, os.Symlink(filepath.Base(target), tmpLink))
+		require.NoError(t, os.Rename(tmpLink, filepath.Join(dir, "..data")))
+	}
+	swap("0")
+	require.NoError(t, os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)))
+	return swap
+}
+
+func TestNewReaderCache_SymlinkSwap(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "SymlinkSwap-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	swap := mkConfigMap(t, tmp, "config.txt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewReaderCache[string](ctx, filepath.Join(tmp, "config.txt"), readString, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, "0", val.MustGet())
+
+	for _, data := range []string{"1", "2"} {
+		swap(data)
+		assert.Eventually(t, func() bool {
+			got, err := val.Get()
+			return err == nil && got == data
+		}, time.Second, 10*time.Millisecond, "A file behind a swapped symlink should be reloaded")
+	}
+
+	files, err := NewDirCache[string](ctx, tmp, readString, testingLog(t))
+	require.NoError(t, err)
+	assert.Equal(t, "2", files.MustGet("config.txt"))
+	swap("3")
+	assert.Eventually(t, func() bool {
+		got, err := files.Get("config.txt")
+		return err == nil && got == "3"
+	}, time.Second, 10*time.Millisecond, "A file in a directory behind a swapped symlink should be reloaded")
+}
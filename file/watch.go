@@ -0,0 +1,193 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// watch starts watching dir, and calls onEvent with each event in dir until ctx is done, or the returned stop function is called.
+// The directory is added to the watcher before watch returns, so no events are missed once a cache is returned to the caller.
+// If a debounce window is set in opts, then events for the same file are combined until they settle.
+// If a polling interval is set in opts, then dir is polled for changes instead of using fsnotify.
+func watch(ctx context.Context, dir string, log NotifyLog, opts options, onEvent func(evt fsnotify.Event)) (stop func(), err error) {
+	if opts.poll > 0 {
+		return poll(ctx, dir, log, opts, onEvent)
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create filesystem watcher: %w", err)
+	}
+	if err := watcher.Add(dir); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("failed to add directory '%s' to watcher: %w", dir, err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	if opts.debounce > 0 {
+		onEvent = debounce(ctx, opts.debounce, onEvent)
+	}
+	go func() {
+		defer func() {
+			err := watcher.Close()
+			if err != nil {
+				log.Error(fmt.Errorf("failed to close watcher for goroutine exit: %w", err))
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-watcher.Events:
+				if !ok || ctx.Err() != nil {
+					// Events may still be queued after ctx is done, and they should no longer be dispatched.
+					return
+				}
+				onEvent(evt)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error(fmt.Errorf("error watching directory '%s': %w", dir, err))
+			}
+		}
+	}()
+	return cancel, nil
+}
+
+// poll is the same as watch, except that dir is read every polling interval, and events are produced for each file that was created, removed, or changed in size, modification time, or mode.
+func poll(ctx context.Context, dir string, log NotifyLog, opts options, onEvent func(evt fsnotify.Event)) (stop func(), err error) {
+	state, err := pollDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	if opts.debounce > 0 {
+		onEvent = debounce(ctx, opts.debounce, onEvent)
+	}
+	go func() {
+		ticker := time.NewTicker(opts.poll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			next, err := pollDir(dir)
+			if err != nil {
+				log.Error(fmt.Errorf("error polling directory '%s': %w", dir, err))
+				continue
+			}
+			for _, evt := range pollEvents(dir, state, next) {
+				if ctx.Err() != nil {
+					return
+				}
+				onEvent(evt)
+			}
+			state = next
+		}
+	}()
+	return cancel, nil
+}
+
+// polledFile is the state of a file that's compared between polls to detect changes.
+type polledFile struct {
+	size    int64
+	modTime time.Time
+	mode    fs.FileMode
+}
+
+func pollDir(dir string) (map[string]polledFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory '%s': %w", dir, err)
+	}
+	state := make(map[string]polledFile, len(entries))
+	for _, entry := range entries {
+		fi, err := entry.Info()
+		if err != nil {
+			// Removed since the directory was read.
+			continue
+		}
+		state[entry.Name()] = polledFile{
+			size:    fi.Size(),
+			modTime: fi.ModTime(),
+			mode:    fi.Mode(),
+		}
+	}
+	return state, nil
+}
+
+// pollEvents returns an event for each difference between the prev and next state of dir.
+func pollEvents(dir string, prev, next map[string]polledFile) []fsnotify.Event {
+	var events []fsnotify.Event
+	for name, file := range next {
+		before, ok := prev[name]
+		var op fsnotify.Op
+		switch {
+		case !ok:
+			op = fsnotify.Create
+		case before.size != file.size || !before.modTime.Equal(file.modTime):
+			op = fsnotify.Write
+		case before.mode != file.mode:
+			op = fsnotify.Chmod
+		default:
+			continue
+		}
+		events = append(events, fsnotify.Event{Name: filepath.Join(dir, name), Op: op})
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			events = append(events, fsnotify.Event{Name: filepath.Join(dir, name), Op: fsnotify.Remove})
+		}
+	}
+	return events
+}
+
+// debounce returns a function that combines events for the same file, and calls onEvent with the combined event once no more events have been received for window.
+// Calls to onEvent are serialized, and stop once ctx is done.
+func debounce(ctx context.Context, window time.Duration, onEvent func(evt fsnotify.Event)) func(evt fsnotify.Event) {
+	var (
+		mux      sync.Mutex
+		dispatch sync.Mutex
+		pending  = map[string]*debounced{}
+	)
+	return func(evt fsnotify.Event) {
+		mux.Lock()
+		defer mux.Unlock()
+		if d, ok := pending[evt.Name]; ok {
+			d.evt.Op |= evt.Op
+			d.timer.Reset(window)
+			return
+		}
+		d := &debounced{evt: evt}
+		d.timer = time.AfterFunc(window, func() {
+			mux.Lock()
+			if pending[evt.Name] != d {
+				// The timer was reset after it fired, and the combined event was already dispatched.
+				mux.Unlock()
+				return
+			}
+			delete(pending, evt.Name)
+			settled := d.evt
+			mux.Unlock()
+			dispatch.Lock()
+			defer dispatch.Unlock()
+			if ctx.Err() != nil {
+				return
+			}
+			onEvent(settled)
+		})
+		pending[evt.Name] = d
+	}
+}
+
+type debounced struct {
+	evt   fsnotify.Event
+	timer *time.Timer
+}
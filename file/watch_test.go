@@ -0,0 +1,132 @@
+package file
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithDebounce(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WithDebounce-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("0"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var invalidated atomic.Int32
+	val, err := NewReaderCache[string](ctx, filename, func(reader io.Reader) (string, error) {
+		data, err := io.ReadAll(reader)
+		return string(data), err
+	}, testingLog(t), WithDebounce(100*time.Millisecond))
+	require.NoError(t, err)
+	val.OnInvalidate(func() {
+		invalidated.Add(1)
+	})
+	assert.Equal(t, "0", val.MustGet())
+
+	for _, data := range []string{"1", "2", "3"} {
+		require.NoError(t, os.WriteFile(filename, []byte(data), 0644))
+		require.NoError(t, os.Chmod(filename, 0600))
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int32(0), invalidated.Load(), "Events should not be dispatched until they settle")
+	assert.Eventually(t, func() bool {
+		return invalidated.Load() > 0
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, int32(1), invalidated.Load(), "A burst of events should settle into a single invalidation")
+	assert.Equal(t, "3", val.MustGet())
+
+	assert.Panics(t, func() {
+		WithDebounce(0)
+	})
+}
+
+func TestWithPolling(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WithPolling-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("Hello!"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewEagerFileCache(ctx, filename, testingLog(t), WithPolling(20*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Hello!"), val.MustGet())
+
+	require.NoError(t, os.WriteFile(filename, []byte("Another message"), 0644))
+	assert.Eventually(t, func() bool {
+		data, err := val.Get()
+		return err == nil && string(data) == "Another message"
+	}, time.Second, 10*time.Millisecond, "A changed file should be detected by polling")
+
+	files, err := NewGlobCache[string](ctx, filepath.Join(tmp, "*.yaml"), func(reader io.Reader) (string, error) {
+		data, err := io.ReadAll(reader)
+		return string(data), err
+	}, testingLog(t), WithPolling(20*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "a.yaml"), []byte("A"), 0644))
+	assert.Eventually(t, func() bool {
+		val, ok := files.GetIfPresent("a.yaml")
+		return ok && val == "A"
+	}, time.Second, 10*time.Millisecond, "A created file should be detected by polling")
+	require.NoError(t, os.Remove(filepath.Join(tmp, "a.yaml")))
+	assert.Eventually(t, func() bool {
+		return files.Len() == 0
+	}, time.Second, 10*time.Millisecond, "A removed file should be detected by polling")
+
+	assert.Panics(t, func() {
+		WithPolling(0)
+	})
+}
+
+func TestWithContentHash(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WithContentHash-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("Hello!"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewEagerFileCache(ctx, filename, testingLog(t), WithContentHash())
+	require.NoError(t, err)
+	var invalidated atomic.Int32
+	val.OnInvalidate(func() {
+		invalidated.Add(1)
+	})
+
+	// Rewriting in place without truncating, so the contents are never observed as empty.
+	f, err := os.OpenFile(filename, os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("Hello!")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Chmod(filename, 0600))
+	now := time.Now()
+	require.NoError(t, os.Chtimes(filename, now, now))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), invalidated.Load(), "Events that don't change the contents should be ignored")
+
+	require.NoError(t, os.WriteFile(filename, []byte("Changed"), 0600))
+	assert.Eventually(t, func() bool {
+		data, err := val.Get()
+		return err == nil && string(data) == "Changed"
+	}, time.Second, 10*time.Millisecond, "A change to the contents should invalidate the value")
+	assert.Greater(t, invalidated.Load(), int32(0))
+}
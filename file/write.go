@@ -0,0 +1,117 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"github.com/saylorsolutions/cache"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Writable is a file cache that can also write a new value back to its file, for applications that own their config file.
+// Methods of the embedded [cache.Value] may be used as usual.
+type Writable[T any] struct {
+	*cache.Value[T]
+	mux       sync.Mutex
+	filename  string
+	writeFunc func(io.Writer, T) error
+	reload    *reloads[T]
+	log       NotifyLog
+}
+
+// NewWritableFileCache is the same as NewFileCache, except that the returned cache may also write new contents to the file.
+// See NewWritableReaderCache for more details.
+func NewWritableFileCache(ctx context.Context, filename string, log NotifyLog, opts ...Option) (*Writable[[]byte], error) {
+	return NewWritableReaderCache[[]byte](ctx, filename, io.ReadAll, func(w io.Writer, data []byte) error {
+		_, err := w.Write(data)
+		return err
+	}, log, opts...)
+}
+
+// NewWritableReaderCache is the same as NewReaderCache, except that the returned cache may also write a value back to the file with writeFunc.
+// Use [WithContentHash] to avoid reloading the file again when the watcher sees the write.
+//
+// This function will panic if writeFunc is nil.
+func NewWritableReaderCache[T any](ctx context.Context, filename string, readFunc func(io.Reader) (T, error), writeFunc func(io.Writer, T) error, log NotifyLog, opts ...Option) (*Writable[T], error) {
+	if writeFunc == nil {
+		panic("nil write func")
+	}
+	orig := filename
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path for '%s': %w", orig, err)
+	}
+	_cache, reload, err := newReaderCache[T](ctx, filename, readFunc, log, opts)
+	if err != nil {
+		return nil, err
+	}
+	if log == nil {
+		log = newNoOpNotifyLog()
+	}
+	return &Writable[T]{
+		Value:     _cache,
+		filename:  filename,
+		writeFunc: writeFunc,
+		reload:    reload,
+		log:       log,
+	}, nil
+}
+
+// Set writes val to the file, and reloads the cached value from it.
+// The file is written atomically by writing to a temporary file in the same directory, and renaming it over the original, so readers never observe a partially written file.
+// If the file is a symlink, then its target is replaced, and the symlink is left in place.
+// The file's permissions are preserved, and a new file is created with 0644 permissions.
+//
+// Once Set returns without an error, Get returns the value read from the new contents.
+// Handlers set with OnInvalidate or [WithOnReload] are called as they would be for any other change to the file.
+func (w *Writable[T]) Set(val T) error {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if err := w.write(val); err != nil {
+		return err
+	}
+	w.reload.invalidate(w.Value, w.log)
+	_, err := w.Get()
+	return err
+}
+
+func (w *Writable[T]) write(val T) error {
+	target := w.filename
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+	perm := fs.FileMode(0644)
+	if fi, err := os.Stat(target); err == nil {
+		perm = fi.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for '%s': %w", target, err)
+	}
+	defer func() {
+		// Cleans up the temporary file if it wasn't renamed.
+		_ = os.Remove(tmp.Name())
+	}()
+	if err := w.writeFunc(tmp, val); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write file '%s' contents: %w", target, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync file '%s' contents: %w", target, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file for '%s': %w", target, err)
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("failed to set permissions of temporary file for '%s': %w", target, err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("failed to replace file '%s': %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package file
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWritable_Set(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WritableSet-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("Hello!"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewWritableFileCache(ctx, filename, testingLog(t), WithContentHash())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("Hello!"), val.MustGet())
+
+	require.NoError(t, val.Set([]byte("Updated")))
+	assert.Equal(t, []byte("Updated"), val.MustGet(), "The new value should be read immediately")
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", string(data))
+	fi, err := os.Stat(filename)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm(), "Permissions should be preserved")
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "The temporary file should not be left behind")
+
+	// The watcher should still detect changes made by others.
+	require.NoError(t, os.WriteFile(filename, []byte("External"), 0600))
+	assert.Eventually(t, func() bool {
+		data, err := val.Get()
+		return err == nil && string(data) == "External"
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Panics(t, func() {
+		_, _ = NewWritableReaderCache[string](ctx, filename, readString, nil, nil)
+	})
+}
+
+func TestWritable_SetSymlink(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WritableSetSymlink-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	target := filepath.Join(tmp, "target.txt")
+	link := filepath.Join(tmp, "link.txt")
+	require.NoError(t, os.WriteFile(target, []byte("A"), 0644))
+	require.NoError(t, os.Symlink(target, link))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	val, err := NewWritableReaderCache[string](ctx, link, readString, func(w io.Writer, s string) error {
+		_, err := io.WriteString(w, s)
+		return err
+	}, testingLog(t))
+	require.NoError(t, err)
+	require.NoError(t, val.Set("B"))
+	assert.Equal(t, "B", val.MustGet())
+	fi, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, fi.Mode().Type(), "The symlink should be left in place")
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "B", string(data))
+}
+
+func TestNewWritableJSONCache(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WritableJSON-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "config.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan [2]_testConfig, 10)
+	val, err := NewWritableJSONCache[_testConfig](ctx, filename, testingLog(t), WithDefault(_testConfig{Port: 8080}), WithOnReload(func(old, new _testConfig) {
+		reloads <- [2]_testConfig{old, new}
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 8080, val.MustGet().Port, "The default should be used until the file is written")
+
+	require.NoError(t, val.Set(_testConfig{Name: "app", Port: 9090}))
+	assert.Equal(t, _testConfig{Name: "app", Port: 9090}, val.MustGet())
+	select {
+	case reload := <-reloads:
+		assert.Equal(t, 8080, reload[0].Port)
+		assert.Equal(t, 9090, reload[1].Port)
+	case <-time.After(time.Second):
+		t.Fatal("Reload func should have been called by Set")
+	}
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"port":9090`)
+}
+
+func TestWritable_SetConcurrentReloads(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "WritableSetConcurrent-*")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmp))
+	}()
+	filename := filepath.Join(tmp, "test.txt")
+	require.NoError(t, os.WriteFile(filename, []byte("0"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		inFlight   atomic.Int32
+		overlapped atomic.Bool
+		mux        sync.Mutex
+		reloads    [][2]string
+	)
+	val, err := NewWritableReaderCache[string](ctx, filename, readString, func(w io.Writer, s string) error {
+		_, err := io.WriteString(w, s)
+		return err
+	}, testingLog(t), WithOnReload(func(old, new string) {
+		if inFlight.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		defer inFlight.Add(-1)
+		time.Sleep(time.Millisecond)
+		mux.Lock()
+		reloads = append(reloads, [2]string{old, new})
+		mux.Unlock()
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "0", val.MustGet())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 20; i++ {
+			assert.NoError(t, val.Set("set-"+strconv.Itoa(i)))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// External changes are also written atomically, so a partially written file is never reloaded.
+		for i := 1; i <= 20; i++ {
+			temp := filepath.Join(tmp, "external.tmp")
+			assert.NoError(t, os.WriteFile(temp, []byte("external-"+strconv.Itoa(i)), 0644))
+			assert.NoError(t, os.Rename(temp, filename))
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	wg.Wait()
+	// Let reloads from the watcher settle.
+	time.Sleep(200 * time.Millisecond)
+
+	assert.False(t, overlapped.Load(), "Reload funcs should not be called concurrently")
+	mux.Lock()
+	defer mux.Unlock()
+	require.NotEmpty(t, reloads)
+	assert.Equal(t, "0", reloads[0][0])
+	for i := 1; i < len(reloads); i++ {
+		assert.Equal(t, reloads[i-1][1], reloads[i][0], "Each reload should report the value loaded by the one before it")
+	}
+}
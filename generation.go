@@ -0,0 +1,43 @@
+package cache
+
+// BumpGeneration logically invalidates every value currently held in the MultiCache in constant time.
+// Each entry records the generation it was created in, and entries from an earlier generation are treated as absent, so they're reloaded the next time their key is requested.
+// This is much cheaper than invalidating a very large MultiCache key by key, which would hold the write lock for the entire operation.
+//
+// Outdated values aren't released until their key is requested again or RemoveExpired is called, and any OnEvictFunc will be called with [ReasonGeneration] at that time.
+// Unlike Invalidate, no OnInvalidateFunc or OnAnyInvalidate handler will be called, and child caches are not affected.
+func (m *MultiCache[K, V]) BumpGeneration() {
+	m.generation.Add(1)
+	if filter := m.missing.Load(); filter != nil {
+		m.missing.Store(filter.empty())
+	}
+}
+
+// Generation returns the current generation of the MultiCache, which starts at 0 and is incremented by BumpGeneration.
+func (m *MultiCache[K, V]) Generation() uint64 {
+	return m.generation.Load()
+}
+
+// isOutdated returns true if the entry was created before the current generation.
+func (m *MultiCache[K, V]) isOutdated(entry *multiEntry[K, V]) bool {
+	return entry.generation != m.generation.Load()
+}
+
+// removeOutdatedLocked removes every entry from an earlier generation, and returns the number of entries removed.
+// Every entry is only inspected once per call to BumpGeneration, so RemoveExpired stays cheap otherwise.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) removeOutdatedLocked() int {
+	gen := m.generation.Load()
+	if m.sweptGen == gen {
+		return 0
+	}
+	var removed int
+	for key, entry := range m.values {
+		if entry.generation != gen {
+			m.remove(key, ReasonGeneration)
+			removed++
+		}
+	}
+	m.sweptGen = gen
+	return removed
+}
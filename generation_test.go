@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_BumpGeneration(t *testing.T) {
+	var loads int
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		loads++
+		return loads, nil
+	})
+	var (
+		invalidated bool
+		evicted     []Reason
+	)
+	mc.OnAnyInvalidate(func(key string) {
+		invalidated = true
+	})
+	mc.OnEvict(func(_ string, _ int, reason Reason) {
+		evicted = append(evicted, reason)
+	})
+
+	assert.Equal(t, 1, mc.MustGet("a"))
+	assert.Equal(t, 2, mc.MustGet("b"))
+	mc.Set("c", 100)
+	assert.Equal(t, uint64(0), mc.Generation())
+
+	mc.BumpGeneration()
+	assert.Equal(t, uint64(1), mc.Generation())
+	assert.Equal(t, 0, mc.Len())
+	_, ok := mc.GetIfPresent("c")
+	assert.False(t, ok)
+
+	assert.Equal(t, 3, mc.MustGet("a"), "outdated values should be reloaded")
+	assert.Equal(t, 3, mc.MustGet("a"))
+	mc.Set("c", 200)
+	assert.Equal(t, 200, mc.MustGet("c"))
+	assert.Equal(t, 2, mc.Len())
+	assert.Equal(t, []Reason{ReasonGeneration, ReasonGeneration}, evicted)
+
+	assert.Equal(t, 1, mc.RemoveExpired(), "only b should still be outdated")
+	assert.Equal(t, 0, mc.RemoveExpired())
+	keys := mc.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "c"}, keys)
+	assert.False(t, invalidated)
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"time"
+)
+
+// SetMaxIdle sets the maximum time that a value may go without being accessed before it's removed, even if its time to live hasn't elapsed.
+// This keeps the memory held by a MultiCache proportional to its working set, rather than every key that was ever requested.
+// A value is considered accessed when it's loaded, set, or read with Get, GetIfPresent, or GetMulti.
+//
+// An idle value will be reloaded the next time its key is requested, and idle values are released by RemoveExpired.
+// This method will panic if maxIdle <= 0.
+func (m *MultiCache[K, V]) SetMaxIdle(maxIdle time.Duration) {
+	if maxIdle <= 0 {
+		panic("max idle <= 0")
+	}
+	m.maxIdle.Store(int64(maxIdle))
+}
+
+// isIdle returns true if the entry hasn't been accessed within the maximum idle time.
+func (m *MultiCache[K, V]) isIdle(entry *multiEntry[K, V]) bool {
+	maxIdle := time.Duration(m.maxIdle.Load())
+	if maxIdle <= 0 || entry.pinned.Load() {
+		return false
+	}
+	last := entry.lastAccess.Load()
+	return last > 0 && time.Since(time.Unix(0, last)) > maxIdle
+}
+
+// touchIdle records an access of the entry for key, and schedules its removal if it becomes idle.
+func (m *MultiCache[K, V]) touchIdle(key K, entry *multiEntry[K, V]) {
+	now := time.Now()
+	entry.lastAccess.Store(now.UnixNano())
+	maxIdle := time.Duration(m.maxIdle.Load())
+	if maxIdle <= 0 || entry.pinned.Load() {
+		return
+	}
+	m.idle.schedule(key, now.Add(maxIdle))
+}
+
+// removeIdle removes the entry for key if it's still held by the MultiCache and idle.
+func (m *MultiCache[K, V]) removeIdle(key K, entry *multiEntry[K, V]) {
+	m.lock.Lock()
+	defer m.unlock()
+	if m.values[key] == entry && m.isIdle(entry) {
+		m.remove(key, ReasonIdle)
+	}
+}
+
+// removeIdleLocked removes every entry that has been idle since before now, and returns the number of entries removed.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) removeIdleLocked(now time.Time) int {
+	maxIdle := time.Duration(m.maxIdle.Load())
+	if maxIdle <= 0 {
+		return 0
+	}
+	var removed int
+	for _, item := range m.idle.popDue(now) {
+		entry, ok := m.values[item.key]
+		if !ok || entry.pinned.Load() {
+			continue
+		}
+		// The scheduled time may be stale if the entry was accessed concurrently.
+		if at := time.Unix(0, entry.lastAccess.Load()).Add(maxIdle); at.After(now) {
+			m.idle.schedule(item.key, at)
+			continue
+		}
+		m.remove(item.key, ReasonIdle)
+		removed++
+	}
+	return removed
+}
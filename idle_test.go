@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetMaxIdle(t *testing.T) {
+	var loads int
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		loads++
+		return loads, nil
+	})
+	mc.SetTTLPolicy(time.Hour)
+	mc.SetMaxIdle(40 * time.Millisecond)
+
+	var evicted []Reason
+	mc.OnEvict(func(_ string, _ int, reason Reason) {
+		evicted = append(evicted, reason)
+	})
+
+	assert.NoError(t, mc.Preheat([]string{"active", "idle"}))
+	for i := 0; i < 3; i++ {
+		time.Sleep(20 * time.Millisecond)
+		_, ok := mc.GetIfPresent("active")
+		assert.True(t, ok)
+	}
+	_, ok := mc.GetIfPresent("idle")
+	assert.False(t, ok, "Idle values should not be returned")
+
+	assert.Equal(t, 1, mc.RemoveExpired())
+	assert.Equal(t, []Reason{ReasonIdle}, evicted)
+	assert.Equal(t, []string{"active"}, mc.Keys())
+	assert.Equal(t, uint64(1), mc.Stats().Evictions)
+
+	// An idle value is reloaded when requested.
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 3, mc.MustGet("active"))
+	assert.Equal(t, "idle", ReasonIdle.String())
+}
@@ -0,0 +1,20 @@
+package cache
+
+// Cache is the common interface implemented by caches of values of type V, keyed by K.
+// Application code may depend on Cache rather than a specific implementation, so it can be unit tested without real loaders.
+// See [github.com/saylorsolutions/cache/cachetest] for a scriptable implementation for tests.
+type Cache[K comparable, V any] interface {
+	// Get returns the value associated with key, loading it if needed.
+	Get(key K) (V, error)
+	// Set caches val for key, replacing any existing value.
+	Set(key K, val V)
+	// Invalidate removes the value associated with key, so it will be loaded again the next time it's requested.
+	Invalidate(key K)
+	// Preheat loads the values associated with each key in keys.
+	Preheat(keys []K) error
+}
+
+var (
+	_ Cache[string, string] = (*MultiCache[string, string])(nil)
+	_ Cache[string, string] = (*ShardedMultiCache[string, string])(nil)
+)
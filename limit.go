@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// loadLimiter bounds the number of loads that may run at the same time.
+type loadLimiter struct {
+	slots chan struct{}
+}
+
+// acquire waits for a free slot, or until ctx is done.
+func (l *loadLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *loadLimiter) release() {
+	<-l.slots
+}
+
+// limitLoader returns a LoaderFunc that calls loader once a slot in l is free.
+// If ctx is done before a slot is free, then the context's error is returned without calling loader.
+func limitLoader[V any](ctx context.Context, l *loadLimiter, loader LoaderFunc[V]) LoaderFunc[V] {
+	return func() (V, error) {
+		if err := l.acquire(ctx); err != nil {
+			var mt V
+			return mt, err
+		}
+		defer l.release()
+		return loader()
+	}
+}
+
+// SetMaxConcurrentLoads limits the number of loads that may run at the same time across the MultiCache to n.
+// Requests that need to load a value once the limit is reached will wait for another load to finish.
+// This protects the underlying data source when many keys are missed at once, such as when a cold cache receives traffic at startup.
+//
+// Requests that are waiting for a load may be cancelled with the context passed to GetCtx.
+// Requests for values that are already cached are never delayed.
+// This method will panic if n <= 0.
+func (m *MultiCache[K, V]) SetMaxConcurrentLoads(n int) {
+	if n <= 0 {
+		panic("max concurrent loads <= 0")
+	}
+	m.limiter.Store(&loadLimiter{slots: make(chan struct{}, n)})
+}
+
+// limitRefresh is the same as limitLoader, but for a LoaderTTLFunc used to refresh a value in the background.
+func limitRefresh[V any](l *loadLimiter, loader LoaderTTLFunc[V]) LoaderTTLFunc[V] {
+	return func() (V, time.Duration, error) {
+		if err := l.acquire(context.Background()); err != nil {
+			var mt V
+			return mt, 0, err
+		}
+		defer l.release()
+		return loader()
+	}
+}
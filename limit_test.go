@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetMaxConcurrentLoads(t *testing.T) {
+	var (
+		running atomic.Int64
+		peak    atomic.Int64
+	)
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return key, nil
+	})
+	mc.SetMaxConcurrentLoads(3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, i, mc.MustGet(i))
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, peak.Load(), int64(3))
+	assert.Equal(t, 20, mc.Len())
+
+	assert.Panics(t, func() {
+		mc.SetMaxConcurrentLoads(0)
+	})
+}
+
+func TestMultiCache_SetMaxConcurrentLoads_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	mc := NewMultiCtx[int, int](func(ctx context.Context, key int) (int, error) {
+		if key == 0 {
+			<-release
+		}
+		return key, nil
+	})
+	mc.SetMaxConcurrentLoads(1)
+	go mc.MustGet(0)
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := mc.GetCtx(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		val, err := mc.Get(1)
+		return err == nil && val == 1
+	}, time.Second, 5*time.Millisecond)
+}
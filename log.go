@@ -0,0 +1,33 @@
+package cache
+
+// LogFunc logs msg with alternating key and value pairs.
+// This matches the signature of logging methods such as slog.Logger.Info and zap.SugaredLogger.Infow, so they may be used directly.
+type LogFunc func(msg string, keysAndValues ...any)
+
+// LogEvents returns an EventHandler that logs each load, load error, invalidation, and eviction of a cache.
+// Hits and misses aren't logged, since they're usually too frequent to be useful in logs, and are better observed with Stats.
+//
+// Each message includes the cache's source name, the key, and the outcome, along with the duration of loads, the reason for evictions, and the error for failed loads.
+// Load errors are logged with errorLog, and everything else is logged with info.
+// If errorLog is nil, then info is used for everything.
+// This method will panic if info is nil.
+func LogEvents(info LogFunc, errorLog LogFunc) EventHandler {
+	if info == nil {
+		panic("nil info log func")
+	}
+	if errorLog == nil {
+		errorLog = info
+	}
+	return func(event Event) {
+		switch event.Type {
+		case EventLoad:
+			info("cache value loaded", "cache", event.Source, "key", event.Key, "outcome", "loaded", "duration", event.Duration)
+		case EventLoadError:
+			errorLog("cache value failed to load", "cache", event.Source, "key", event.Key, "outcome", "load error", "duration", event.Duration, "error", event.Err)
+		case EventInvalidate:
+			info("cache value invalidated", "cache", event.Source, "key", event.Key, "outcome", "invalidated")
+		case EventEvict:
+			info("cache value evicted", "cache", event.Source, "key", event.Key, "outcome", "evicted", "reason", event.Reason.String())
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogEvents(t *testing.T) {
+	var (
+		infos []string
+		errs  []string
+	)
+	format := func(msg string, keysAndValues ...any) string {
+		var sb strings.Builder
+		sb.WriteString(msg)
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			if keysAndValues[i] == "duration" {
+				continue
+			}
+			_, _ = fmt.Fprintf(&sb, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		}
+		return sb.String()
+	}
+	logger := LogEvents(func(msg string, keysAndValues ...any) {
+		infos = append(infos, format(msg, keysAndValues...))
+	}, func(msg string, keysAndValues ...any) {
+		errs = append(errs, format(msg, keysAndValues...))
+	})
+
+	var hits int
+	mc := newLogTestCache()
+	mc.SetEventHandler("test", EventHandlers(logger, nil, func(event Event) {
+		if event.Type == EventHit {
+			hits++
+		}
+	}))
+	mc.MustGet(1)
+	mc.MustGet(1)
+	_, _ = mc.Get(-1)
+	mc.MustGet(2)
+	mc.Remove(1)
+	mc.Invalidate(2)
+
+	assert.Equal(t, []string{
+		"cache value loaded cache=test key=1 outcome=loaded",
+		"cache value loaded cache=test key=2 outcome=loaded",
+		"cache value evicted cache=test key=1 outcome=evicted reason=removed",
+		"cache value invalidated cache=test key=2 outcome=invalidated",
+	}, infos)
+	assert.Equal(t, []string{
+		"cache value failed to load cache=test key=-1 outcome=load error error=negative key",
+	}, errs)
+	assert.Equal(t, 1, hits)
+
+	assert.Panics(t, func() {
+		LogEvents(nil, nil)
+	})
+}
+
+func newLogTestCache() *MultiCache[int, int] {
+	return NewMulti[int, int](func(key int) (int, error) {
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key, nil
+	})
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+)
+
+// MergePolicy determines how [MultiCache.Merge] resolves a key that has a valid value in both caches.
+type MergePolicy int
+
+const (
+	// MergeKeepExisting keeps the value already held by the MultiCache being merged into.
+	MergeKeepExisting MergePolicy = iota
+	// MergeReplace replaces the existing value with the value from the other MultiCache.
+	MergeReplace
+)
+
+func (p MergePolicy) String() string {
+	switch p {
+	case MergeKeepExisting:
+		return "keep existing"
+	case MergeReplace:
+		return "replace"
+	default:
+		return fmt.Sprintf("MergePolicy(%d)", int(p))
+	}
+}
+
+// Clone returns a point-in-time copy of the MultiCache.
+// The clone has the same loader and configuration, such as its TTL policy and capacity limits, and holds a copy of every valid value with the same expiration.
+// Changes to either MultiCache after Clone returns are not reflected in the other.
+//
+// Handlers registered with methods such as OnEvict, OnHit, and OnAnyInvalidate, child caches, and tags added with Tag are not copied.
+// Values are copied as-is, so a cloner set with SetCloner should be used if values must not be shared.
+func (m *MultiCache[K, V]) Clone() *MultiCache[K, V] {
+	m.lock.RLock()
+	c := &MultiCache[K, V]{
+		values:         make(map[K]*multiEntry[K, V], len(m.values)),
+		loader:         m.loader,
+		ctxLoader:      m.ctxLoader,
+		ttlLoader:      m.ttlLoader,
+		batchLoader:    m.batchLoader,
+		keyLister:      m.keyLister,
+		codec:          m.codec,
+		ttl:            m.ttl,
+		ttlJitter:      m.ttlJitter,
+		keyTTLs:        make(map[K]time.Duration, len(m.keyTTLs)),
+		pinned:         make(map[K]struct{}, len(m.pinned)),
+		tags:           map[string]map[K]struct{}{},
+		negativeTTL:    m.negativeTTL,
+		isAbsent:       m.isAbsent,
+		refreshAhead:   m.refreshAhead,
+		maxStale:       m.maxStale,
+		staleOnError:   m.staleOnError,
+		earlyBeta:      m.earlyBeta,
+		maxEntries:     m.maxEntries,
+		evictionPolicy: m.evictionPolicy,
+		weigher:        m.weigher,
+		maxCost:        m.maxCost,
+		expirations:    newExpiryQueue[K](len(m.values)),
+		idle:           newExpiryQueue[K](0),
+	}
+	for key, ttl := range m.keyTTLs {
+		c.keyTTLs[key] = ttl
+	}
+	for key := range m.pinned {
+		c.pinned[key] = struct{}{}
+	}
+	if m.policy != nil {
+		c.policy = newEvictor[K](c.evictionPolicy)
+	}
+	hooks := m.hooks.Load()
+	m.lock.RUnlock()
+	c.maxIdle.Store(m.maxIdle.Load())
+	if batcher := m.batcher.Load(); batcher != nil {
+		c.SetBatchWindow(batcher.window)
+	}
+	if limiter := m.limiter.Load(); limiter != nil {
+		c.SetMaxConcurrentLoads(cap(limiter.slots))
+	}
+	c.loadTimeout.Store(m.loadTimeout.Load())
+	c.adaptive.Store(m.adaptive.Load())
+	if filter := m.missing.Load(); filter != nil {
+		c.missing.Store(filter.empty())
+	}
+	if shedder := m.shedder.Load(); shedder != nil {
+		c.SetLoadShedding(int(shedder.maxInFlight))
+	}
+	c.hooks.Store(&multiHooks[K, V]{
+		tagger:        hooks.tagger,
+		cloner:        hooks.cloner,
+		sizeEstimator: hooks.sizeEstimator,
+	})
+	c.Merge(m, MergeReplace)
+	return c
+}
+
+// Merge copies every valid value from other into the MultiCache, without calling the loader, and returns the number of values copied.
+// If a key has a valid value in both caches, then policy determines which value is kept.
+// Copied values keep the expiration they had in other, unless the MultiCache's own time to live for the key is shorter.
+//
+// This is useful for blue/green swaps of configuration, or for building test fixtures.
+func (m *MultiCache[K, V]) Merge(other *MultiCache[K, V], policy MergePolicy) int {
+	if policy != MergeKeepExisting && policy != MergeReplace {
+		panic(fmt.Sprintf("unknown merge policy: %s", policy))
+	}
+	var merged int
+	for _, ke := range other.entries() {
+		val, at, ok := ke.entry.value.cachedUntil()
+		if !ok {
+			continue
+		}
+		if policy == MergeKeepExisting {
+			if _, loaded := m.GetOrSet(ke.key, val); loaded {
+				continue
+			}
+		} else {
+			m.Set(ke.key, val)
+		}
+		if !at.IsZero() {
+			m.expireBy(ke.key, at)
+		}
+		merged++
+	}
+	return merged
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Clone(t *testing.T) {
+	var loads int
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		loads++
+		return len(key), nil
+	})
+	mc.SetTTLPolicy(time.Hour)
+	mc.SetMaxEntries(2)
+	assert.NoError(t, mc.Preheat([]string{"a", "bb"}))
+
+	clone := mc.Clone()
+	assert.ElementsMatch(t, []string{"a", "bb"}, clone.Keys())
+	assert.Equal(t, 2, clone.MustGet("bb"))
+	assert.Equal(t, 2, loads, "Cloned values should not be loaded again")
+
+	// The clone is independent of the original, but keeps its configuration.
+	mc.Invalidate("a")
+	assert.Equal(t, 1, clone.MustGet("a"))
+	clone.Set("ccc", 30)
+	assert.Equal(t, 2, clone.Len())
+	_, ok := mc.GetIfPresent("ccc")
+	assert.False(t, ok)
+
+	at, ok := clone.NextExpiration()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), at, time.Minute)
+}
+
+func TestMultiCache_Merge(t *testing.T) {
+	newCache := func() *MultiCache[string, string] {
+		return NewMulti[string, string](func(key string) (string, error) {
+			return "", nil
+		})
+	}
+	blue := newCache()
+	blue.PreheatFrom(map[string]string{"a": "blue-a", "b": "blue-b"})
+	green := newCache()
+	green.PreheatFrom(map[string]string{"b": "green-b", "c": "green-c"})
+
+	keep := blue.Clone()
+	assert.Equal(t, 1, keep.Merge(green, MergeKeepExisting))
+	assert.Equal(t, "blue-b", keep.MustGet("b"))
+	assert.Equal(t, "green-c", keep.MustGet("c"))
+
+	assert.Equal(t, 2, blue.Merge(green, MergeReplace))
+	assert.Equal(t, "blue-a", blue.MustGet("a"))
+	assert.Equal(t, "green-b", blue.MustGet("b"))
+	assert.Equal(t, "green-c", blue.MustGet("c"))
+
+	assert.Panics(t, func() {
+		blue.Merge(green, MergePolicy(-1))
+	})
+}
@@ -1,39 +1,336 @@
 package cache
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // MultiLoaderFunc is a lot like LoaderFunc, except that it accepts an input key.
 type MultiLoaderFunc[K comparable, V any] func(key K) (V, error)
 
+// MultiLoaderCtxFunc is a lot like MultiLoaderFunc, except that it also accepts the context of the call that triggered the load.
+// This allows request deadlines, cancellation, and tracing information to propagate into loads.
+type MultiLoaderCtxFunc[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// MultiLoaderTTLFunc is a lot like LoaderTTLFunc, except that it accepts an input key.
+// If a MultiLoaderTTLFunc returns a time to live <= 0, then an error will be returned from [MultiCache.Get] indicating this.
+type MultiLoaderTTLFunc[K comparable, V any] func(key K) (V, time.Duration, error)
+
+// MultiLoaderOptionalFunc is a lot like MultiLoaderFunc, except that it returns false when key definitively doesn't exist in the underlying data source.
+// This lets a loader report absence without creating an error, and lets transient failures be returned as errors.
+type MultiLoaderOptionalFunc[K comparable, V any] func(key K) (V, bool, error)
+
+// KeyLister returns every key that should be held by a MultiCache.
+// This is used by [MultiCache.PreheatAll] for caches that mirror a small, fully known dataset, such as feature flags or locales.
+type KeyLister[K comparable] func() ([]K, error)
+
+// OnInvalidateKeyFunc is a function that will be called with the key of an invalidated MultiCache value.
+type OnInvalidateKeyFunc[K comparable] func(key K)
+
+// Reason describes why a value was removed from a MultiCache.
+type Reason int
+
+const (
+	// ReasonInvalidated indicates that the value was explicitly invalidated.
+	ReasonInvalidated Reason = iota
+	// ReasonExpired indicates that the value was removed after its time to live elapsed.
+	ReasonExpired
+	// ReasonCapacity indicates that the value was evicted to stay within the maximum number of entries.
+	ReasonCapacity
+	// ReasonCost indicates that the value was evicted to stay within the maximum total cost.
+	ReasonCost
+	// ReasonReplaced indicates that the value was replaced by a newly loaded or set value for the same key.
+	ReasonReplaced
+	// ReasonIdle indicates that the value was removed because it wasn't accessed within the maximum idle time.
+	ReasonIdle
+	// ReasonRemoved indicates that the value was explicitly removed with [MultiCache.Remove].
+	ReasonRemoved
+	// ReasonGeneration indicates that the value was removed because it was cached before a call to [MultiCache.BumpGeneration].
+	ReasonGeneration
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonInvalidated:
+		return "invalidated"
+	case ReasonExpired:
+		return "expired"
+	case ReasonCapacity:
+		return "capacity"
+	case ReasonCost:
+		return "cost"
+	case ReasonReplaced:
+		return "replaced"
+	case ReasonIdle:
+		return "idle"
+	case ReasonRemoved:
+		return "removed"
+	case ReasonGeneration:
+		return "generation"
+	default:
+		return fmt.Sprintf("Reason(%d)", int(r))
+	}
+}
+
+// OnAccessFunc is a function that will be called with the key of a value requested from a MultiCache.
+type OnAccessFunc[K comparable] func(key K)
+
+// OnEvictFunc is a function that will be called with a value that has been removed from a MultiCache, along with the [Reason] it was removed.
+type OnEvictFunc[K comparable, V any] func(key K, val V, reason Reason)
+
+// MultiBatchLoaderFunc loads the values for many keys at once, such as with a single database query.
+// The returned map should contain a value for every key that was requested.
+type MultiBatchLoaderFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// Weigher calculates the cost of holding a value in a MultiCache.
+// A Weigher should return a consistent, non-negative cost for the same value, such as its approximate size in bytes.
+type Weigher[K comparable, V any] func(key K, val V) int64
+
+// multiEntry holds a cached Value along with the MultiCache's bookkeeping for its key.
+type multiEntry[K comparable, V any] struct {
+	value *Value[V]
+	// weigher is used to calculate the cost of newly loaded values, and is guarded by the Value's lock.
+	weigher Weigher[K, V]
+	// cost is the applied cost of the value, and is guarded by the MultiCache's write lock.
+	cost int64
+	// pendingCost holds the cost of a newly loaded value until it's applied by the MultiCache.
+	pendingCost atomic.Int64
+	costPending atomic.Bool
+	// refreshing is true while the value is being refreshed in the background.
+	refreshing atomic.Bool
+	// lastAccess is the time of the last access of the value, in Unix nanoseconds.
+	lastAccess atomic.Int64
+	// generation is the generation of the MultiCache when the entry was created, and is never modified.
+	generation uint64
+	// pinned is true if the entry's key is pinned with [MultiCache.Pin].
+	pinned atomic.Bool
+	// loadedAt is the time that the current value was loaded or set, in Unix nanoseconds.
+	loadedAt atomic.Int64
+	// hits counts requests served by the entry's value, for debugging.
+	hits atomic.Uint64
+	// loadDuration is how long the last successful load of the value took, in nanoseconds.
+	loadDuration atomic.Int64
+	// fixedTTL is true if the entry's key has a time to live set with [MultiCache.SetKeyTTL], which isn't adapted.
+	fixedTTL atomic.Bool
+	// adaptiveTTL is the time to live chosen for the current value by [MultiCache.SetAdaptiveTTL], and is guarded by the Value's lock.
+	adaptiveTTL time.Duration
+
+	// absentErr is the error from a load that reported key as absent, which is returned until absentUntil.
+	// Both are guarded by the Value's lock.
+	absentErr   error
+	absentUntil time.Time
+
+	mux sync.Mutex
+	// replaced holds values replaced by a load until the MultiCache can report them with its OnEvictFunc.
+	replaced []V
+	// pendingTags holds the tags of a newly loaded value until they're applied by the MultiCache, and is guarded by mux.
+	pendingTags []string
+	tagsPending bool
+	// tags are the applied tags of the value, and are guarded by the MultiCache's write lock.
+	tags []string
+}
+
+// removal records a value removed from a MultiCache while its write lock was held, so handlers can be called after the lock is released.
+type removal[K comparable, V any] struct {
+	key    K
+	entry  *multiEntry[K, V]
+	reason Reason
+}
+
+// multiHooks holds the handlers registered with a MultiCache.
+// A multiHooks is never modified after it's stored, so handlers can be read without locking the MultiCache.
+type multiHooks[K comparable, V any] struct {
+	onAnyInvalidate OnInvalidateKeyFunc[K]
+	onEvict         OnEvictFunc[K, V]
+	onHit           OnAccessFunc[K]
+	onMiss          OnAccessFunc[K]
+	tagger          Tagger[K, V]
+	children        map[string]childCache[K]
+	cloner          func(val V) V
+	sizeEstimator   Weigher[K, V]
+	normalizer      KeyNormalizer[K]
+	events          *eventSink
+}
+
 // MultiCache provides the ability to cache multiple values of type V by some comparable key K.
 // An example use-case would be caching database entities by primary key.
+//
+// Each value in a MultiCache is loaded independently, and the MultiCache is never locked while a value is loading.
+// This means that a slow load for one key will not block requests for other keys.
 type MultiCache[K comparable, V any] struct {
-	values map[K]*Value[V]
-	loader MultiLoaderFunc[K, V]
-	lock   sync.RWMutex
-	ttl    time.Duration
+	values       map[K]*multiEntry[K, V]
+	loader       MultiLoaderFunc[K, V]
+	ctxLoader    MultiLoaderCtxFunc[K, V]
+	ttlLoader    MultiLoaderTTLFunc[K, V]
+	batchLoader  MultiBatchLoaderFunc[K, V]
+	keyLister    KeyLister[K]
+	codec        Codec[K, V]
+	lock         sync.RWMutex
+	ttl          time.Duration
+	ttlJitter    float64
+	keyTTLs      map[K]time.Duration
+	pinned       map[K]struct{}
+	tags         map[string]map[K]struct{}
+	indexKey     func(key K, added bool)
+	negativeTTL  time.Duration
+	isAbsent     func(err error) bool
+	refreshAhead time.Duration
+	maxStale     time.Duration
+	staleOnError bool
+	earlyBeta    float64
+	hooks        atomic.Pointer[multiHooks[K, V]]
+	removed      []removal[K, V]
+	stats        statsCounter
+
+	maxEntries     int
+	evictionPolicy EvictionPolicy
+	policy         evictor[K]
+	weigher        Weigher[K, V]
+	maxCost        int64
+	totalCost      int64
+	expirations    *expiryQueue[K]
+	maxIdle        atomic.Int64
+	idle           *expiryQueue[K]
+	watchers       watchers[K, V]
+	delayed        delayedInvalidations[K]
+	batcher        atomic.Pointer[microBatcher[K, V]]
+	limiter        atomic.Pointer[loadLimiter]
+	loadTimeout    atomic.Int64
+	adaptive       atomic.Pointer[adaptiveTTL[V]]
+	missing        atomic.Pointer[bloomFilter[K]]
+	shedder        atomic.Pointer[loadShedder]
+	generation     atomic.Uint64
+	sweptGen       uint64
 }
 
 // NewMulti will create a new MultiCache with the given loader.
 // A MultiCache may be composed of other MultiCache in the case where logical grouping of cached values is needed.
 // If the loader is nil, then this function will panic.
 func NewMulti[K comparable, V any](loader MultiLoaderFunc[K, V]) *MultiCache[K, V] {
+	return NewMultiSized[K, V](loader, 0)
+}
+
+// NewMultiSized is the same as NewMulti, except that space for expectedEntries values is allocated up front.
+// This avoids repeatedly growing internal structures while warming a MultiCache with many keys.
+// The MultiCache may still hold more or fewer values than expectedEntries.
+//
+// If the loader is nil or expectedEntries < 0, then this function will panic.
+func NewMultiSized[K comparable, V any](loader MultiLoaderFunc[K, V], expectedEntries int) *MultiCache[K, V] {
 	if loader == nil {
 		panic("nil loader")
 	}
-	return &MultiCache[K, V]{
-		values: map[K]*Value[V]{},
-		loader: loader,
+	if expectedEntries < 0 {
+		panic("expected entries < 0")
+	}
+	m := &MultiCache[K, V]{
+		values:      make(map[K]*multiEntry[K, V], expectedEntries),
+		keyTTLs:     map[K]time.Duration{},
+		pinned:      map[K]struct{}{},
+		tags:        map[string]map[K]struct{}{},
+		loader:      loader,
+		expirations: newExpiryQueue[K](expectedEntries),
+		idle:        newExpiryQueue[K](0),
+	}
+	m.hooks.Store(&multiHooks[K, V]{})
+	return m
+}
+
+// NewMultiCtx will create a new MultiCache with a context-aware loader.
+// Loads triggered by GetCtx will pass the caller's context to the loader, while other methods that load values will pass [context.Background].
+//
+// If the loader is nil, then this function will panic.
+func NewMultiCtx[K comparable, V any](loader MultiLoaderCtxFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	m := NewMulti[K, V](func(key K) (V, error) {
+		return loader(context.Background(), key)
+	})
+	m.ctxLoader = loader
+	return m
+}
+
+// NewMultiWithTTL will create a new MultiCache where the loader determines each value's time to live.
+// This is useful when the validity of each value is determined by the data source, such as token expiration, Cache-Control headers, or row timestamps.
+//
+// The time to live returned by the loader takes precedence over SetTTLPolicy and SetKeyTTL.
+// If the loader is nil, then this function will panic.
+func NewMultiWithTTL[K comparable, V any](loader MultiLoaderTTLFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
 	}
+	m := NewMulti[K, V](func(key K) (V, error) {
+		val, _, err := loader(key)
+		return val, err
+	})
+	m.ttlLoader = loader
+	return m
+}
+
+// NewMultiOptional will create a new MultiCache with a loader that reports whether each key exists.
+// When the loader returns false, [MultiCache.Get] will return [ErrKeyNotFound] for the key, and the absence may be cached with SetNegativeTTL.
+//
+// If the loader is nil, then this function will panic.
+func NewMultiOptional[K comparable, V any](loader MultiLoaderOptionalFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return NewMulti[K, V](func(key K) (V, error) {
+		val, ok, err := loader(key)
+		if err != nil {
+			var mt V
+			return mt, err
+		}
+		if !ok {
+			var mt V
+			return mt, ErrKeyNotFound
+		}
+		return val, nil
+	})
+}
+
+// NewMultiBatch will create a new MultiCache with the given batch loader.
+// GetMulti and Preheat will load all missing keys with a single call to the batch loader, rather than a call per key.
+// Get will call the batch loader with a single key.
+// If the batch loader doesn't return a value for a requested key, then an error will be returned for that key.
+//
+// If the loader is nil, then this function will panic.
+func NewMultiBatch[K comparable, V any](loader MultiBatchLoaderFunc[K, V]) *MultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	m := NewMulti[K, V](func(key K) (V, error) {
+		vals, err := loader([]K{key})
+		if err != nil {
+			var mt V
+			return mt, err
+		}
+		val, ok := vals[key]
+		if !ok {
+			return val, missingFromBatch(key)
+		}
+		return val, nil
+	})
+	m.batchLoader = loader
+	return m
 }
 
 // Preheat will load the values associated with each key in keys.
 // This will return the first error encountered and stop processing further keys.
+// If the MultiCache was created with NewMultiBatch, then all keys will be loaded with a single call to the batch loader.
 func (m *MultiCache[K, V]) Preheat(keys []K) error {
+	if m.batchLoader != nil {
+		_, err := m.GetMulti(keys)
+		if err != nil {
+			return fmt.Errorf("error preheating cache: %w", err)
+		}
+		return nil
+	}
 	for _, key := range keys {
 		_, err := m.Get(key)
 		if err != nil {
@@ -43,18 +340,371 @@ func (m *MultiCache[K, V]) Preheat(keys []K) error {
 	return nil
 }
 
+// PreheatEach is the same as Preheat, except that it will attempt to load every key, even if some of them fail.
+// This prevents a single bad key from leaving the rest of the MultiCache cold.
+// If any keys fail to load, then an error joining every failure will be returned.
+// If the MultiCache was created with NewMultiBatch, then this is the same as Preheat, since every key is already attempted by the batch loader.
+func (m *MultiCache[K, V]) PreheatEach(keys []K) error {
+	if m.batchLoader != nil {
+		return m.Preheat(keys)
+	}
+	var errs []error
+	for _, key := range keys {
+		_, err := m.Get(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error preheating cache with key '%v': %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PreheatFrom will cache every value in vals by its key, without calling the loader.
+// This is useful when values have already been fetched, such as with a bulk query, and loading them again would be wasteful.
+// Each value is cached as if it were set with Set.
+func (m *MultiCache[K, V]) PreheatFrom(vals map[K]V) {
+	for key, val := range vals {
+		m.Set(key, val)
+	}
+}
+
+// SetKeyLister sets the [KeyLister] used by PreheatAll to enumerate every key.
+// This method will panic if lister is nil.
+func (m *MultiCache[K, V]) SetKeyLister(lister KeyLister[K]) {
+	if lister == nil {
+		panic("nil key lister")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.keyLister = lister
+}
+
+// PreheatAll will load the value for every key returned by the MultiCache's [KeyLister], which must be set with SetKeyLister.
+// Like PreheatEach, every key will be attempted even if some fail, and an error joining every failure will be returned.
+// The given ctx is passed to the loader as in GetCtx, and if ctx is done, then no further keys will be loaded and its error will be returned along with any failures.
+func (m *MultiCache[K, V]) PreheatAll(ctx context.Context) error {
+	m.lock.RLock()
+	lister := m.keyLister
+	m.lock.RUnlock()
+	if lister == nil {
+		return fmt.Errorf("%w: no key lister set", ErrMisconfigured)
+	}
+	keys, err := lister()
+	if err != nil {
+		return fmt.Errorf("error listing keys: %w", err)
+	}
+	if m.batchLoader != nil {
+		return m.Preheat(keys)
+	}
+	var errs []error
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		_, err := m.GetCtx(ctx, key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error preheating cache with key '%v': %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Get will return the value in the cache.Value associated with key K.
-// Any errors returned from [cache.Value.Get] will be returned from Get.
+// Any errors returned from [cache.Value.Get] will be returned from Get, wrapped in a [LoadError].
+//
+// If many goroutines request the same missing key at the same time, then the loader will only be called once, and all callers will share its result, including any error.
 func (m *MultiCache[K, V]) Get(key K) (V, error) {
+	return m.get(context.Background(), key)
+}
+
+// GetCtx is the same as Get, except that ctx will be passed to the loader if the MultiCache was created with NewMultiCtx.
+// Otherwise, ctx is not used.
+func (m *MultiCache[K, V]) GetCtx(ctx context.Context, key K) (V, error) {
+	return m.get(ctx, key)
+}
+
+// get returns the value for key, and passes ctx to the loader if the MultiCache has a context-aware loader.
+func (m *MultiCache[K, V]) get(ctx context.Context, key K) (V, error) {
+	key = m.normalize(key)
 	m.lock.RLock()
-	c, ok := m.values[key]
+	entry, ok := m.values[key]
+	if ok && m.policy != nil {
+		m.policy.touch(key)
+	}
+	negativeTTL, isAbsent, refreshAhead, maxStale, staleOnError, earlyBeta := m.negativeTTL, m.isAbsent, m.refreshAhead, m.maxStale, m.staleOnError, m.earlyBeta
+	m.lock.RUnlock()
+	if ok && m.isIdle(entry) {
+		m.removeIdle(key, entry)
+		ok = false
+	}
+	if !ok || m.isOutdated(entry) {
+		entry = m.populate(key)
+	}
+	m.touchIdle(key, entry)
+	if maxStale > 0 {
+		if val, ok := entry.value.stale(maxStale); ok {
+			m.refreshAsync(key, entry)
+			entry.hits.Add(1)
+			m.recordAccess(key, true)
+			return m.clone(val), nil
+		}
+	}
+
+	loader := entry.value.loadFunc
+	if timeout := time.Duration(m.loadTimeout.Load()); timeout > 0 && m.ttlLoader != nil {
+		// The time to live is applied by ttlLoaderFunc while holding the Value's lock, and only once the load completes in time.
+		loader = ttlLoaderFunc(entry.value, timeoutTTLLoader(ctx, timeout, func(context.Context) (V, time.Duration, error) {
+			return m.ttlLoader(key)
+		}))
+	} else if timeout > 0 {
+		load := loader
+		loader = timeoutLoader(ctx, timeout, func(ctx context.Context) (V, error) {
+			if m.ctxLoader != nil {
+				return m.ctxLoader(ctx, key)
+			}
+			return load()
+		})
+	} else if m.ctxLoader != nil {
+		loader = func() (V, error) {
+			return m.ctxLoader(ctx, key)
+		}
+	}
+	if limiter := m.limiter.Load(); limiter != nil {
+		loader = limitLoader(ctx, limiter, loader)
+	}
+	var shed bool
+	if shedder := m.shedder.Load(); shedder != nil {
+		loader = shedLoader(shedder, loader, &shed)
+	}
+	var negativeHit bool
+	if m.missing.Load() != nil {
+		loader = m.filterLoader(key, loader, isAbsent, &negativeHit)
+	}
+	if negativeTTL > 0 {
+		loader = negativeLoader(entry, loader, negativeTTL, isAbsent, &negativeHit)
+	}
+	// Only the Value's lock is held while loading, so other keys are unaffected.
+	start := time.Now()
+	val, outcome, err := entry.value.getWith(loader)
+	hit := outcome == outcomeCached || (outcome == outcomeShared && err == nil) || negativeHit
+	if hit {
+		entry.hits.Add(1)
+	}
+	m.recordAccess(key, hit)
+	if shed {
+		m.stats.loadsShed.Add(1)
+	}
+	if outcome == outcomeLoaded && !negativeHit && !shed {
+		duration := time.Since(start)
+		m.recordLoad(key, err, duration)
+		if err == nil {
+			entry.loadDuration.Store(int64(duration))
+		}
+	}
+	m.afterLoad(key, entry)
+	if err != nil && staleOnError && !IsKeyNotFound(err) && (isAbsent == nil || !isAbsent(err)) {
+		if prev, ok := entry.value.current(); ok {
+			m.stats.staleServed.Add(1)
+			return m.clone(prev), nil
+		}
+	}
+	if outcome == outcomeCached && (m.shouldRefreshAhead(entry, refreshAhead) || m.shouldRefreshEarly(entry, earlyBeta)) {
+		m.refreshAsync(key, entry)
+	}
+	if err != nil {
+		return val, &LoadError{Key: key, Err: err}
+	}
+	return m.clone(val), nil
+}
+
+// negativeLoader wraps loader so that [ErrKeyNotFound], or an error reported as absent by isAbsent, is cached in entry for ttl.
+// While the absence is cached, the error is returned without calling loader, and negativeHit is set to true.
+// The returned LoaderFunc is called while holding the Value's lock, which guards the entry's absence.
+func negativeLoader[K comparable, V any](entry *multiEntry[K, V], loader LoaderFunc[V], ttl time.Duration, isAbsent func(err error) bool, negativeHit *bool) LoaderFunc[V] {
+	return func() (V, error) {
+		if entry.absentErr != nil && time.Now().Before(entry.absentUntil) {
+			*negativeHit = true
+			var mt V
+			return mt, entry.absentErr
+		}
+		entry.absentErr = nil
+		val, err := loader()
+		if err != nil && (IsKeyNotFound(err) || isAbsent(err)) {
+			entry.absentErr = err
+			entry.absentUntil = time.Now().Add(ttl)
+		}
+		return val, err
+	}
+}
+
+// afterLoad applies any bookkeeping left pending by a load or set of the entry's value.
+// This must be called without holding a lock.
+func (m *MultiCache[K, V]) afterLoad(key K, entry *multiEntry[K, V]) {
+	if entry.costPending.Load() {
+		m.applyCost(key, entry)
+	}
+	entry.mux.Lock()
+	replaced := entry.replaced
+	entry.replaced = nil
+	tags, tagsPending := entry.pendingTags, entry.tagsPending
+	entry.pendingTags, entry.tagsPending = nil, false
+	entry.mux.Unlock()
+	if tagsPending {
+		m.applyTags(key, entry, tags)
+	}
+	if len(replaced) == 0 {
+		return
+	}
+	if onEvict := m.hooks.Load().onEvict; onEvict != nil {
+		for _, val := range replaced {
+			onEvict(key, val, ReasonReplaced)
+		}
+	}
+}
+
+// recordAccess counts a hit or miss for key, and calls the appropriate access handler, if it's set.
+// This must be called without holding a lock, so handlers may use the MultiCache.
+func (m *MultiCache[K, V]) recordAccess(key K, hit bool) {
+	if hit {
+		m.stats.hits.Add(1)
+	} else {
+		m.stats.misses.Add(1)
+	}
+	hooks := m.hooks.Load()
+	switch {
+	case hit && hooks.onHit != nil:
+		hooks.onHit(key)
+	case !hit && hooks.onMiss != nil:
+		hooks.onMiss(key)
+	}
+	if hit {
+		hooks.events.emit(Event{Type: EventHit, Key: key})
+	} else {
+		hooks.events.emit(Event{Type: EventMiss, Key: key})
+	}
+}
+
+// GetIfPresent will return the value associated with key and true if a valid value is cached.
+// Unlike Get, this will never call the loader, so it can be used to distinguish a cached value from one that would need to be loaded.
+// If no valid value is cached, then the zero value of V and false will be returned.
+func (m *MultiCache[K, V]) GetIfPresent(key K) (V, bool) {
+	key = m.normalize(key)
+	var (
+		val V
+		ok  bool
+	)
+	m.lock.RLock()
+	entry, exists := m.values[key]
+	if exists && m.policy != nil {
+		m.policy.touch(key)
+	}
+	m.lock.RUnlock()
+	if exists && !m.isIdle(entry) && !m.isOutdated(entry) {
+		val, ok = entry.value.cached()
+		m.touchIdle(key, entry)
+		if ok {
+			entry.hits.Add(1)
+		}
+	}
+	m.recordAccess(key, ok)
 	if !ok {
-		m.lock.RUnlock()
-		m.populate(key)
-		return m.Get(key)
+		return val, false
 	}
-	defer m.lock.RUnlock()
-	return c.Get()
+	return m.clone(val), true
+}
+
+// GetMulti will return the values associated with each key in keys.
+// Values that are already cached are returned directly, and any misses are loaded concurrently.
+// If the MultiCache was created with NewMultiBatch, then misses will be loaded with a single call to the batch loader instead.
+// This is more efficient than calling Get for each key when many keys are needed at once, such as when rendering a list.
+//
+// If any load fails, then an error will be returned for the first failed key in keys.
+// The returned map will contain every value that was retrieved successfully, even if an error is returned.
+func (m *MultiCache[K, V]) GetMulti(keys []K) (map[K]V, error) {
+	if m.hooks.Load().normalizer == nil {
+		return m.getMulti(keys)
+	}
+	normalized := make([]K, len(keys))
+	for i, key := range keys {
+		normalized[i] = m.normalize(key)
+	}
+	vals, err := m.getMulti(normalized)
+	result := make(map[K]V, len(keys))
+	for i, key := range keys {
+		if val, ok := vals[normalized[i]]; ok {
+			result[key] = val
+		}
+	}
+	return result, err
+}
+
+// getMulti implements GetMulti for keys that have already been normalized.
+func (m *MultiCache[K, V]) getMulti(keys []K) (map[K]V, error) {
+	var (
+		result = make(map[K]V, len(keys))
+		misses []K
+		missed = map[K]bool{}
+	)
+	m.lock.RLock()
+	for _, key := range keys {
+		if _, ok := result[key]; ok || missed[key] {
+			continue
+		}
+		if entry, ok := m.values[key]; ok && !m.isIdle(entry) && !m.isOutdated(entry) {
+			if val, ok := entry.value.TryGet(); ok {
+				if m.policy != nil {
+					m.policy.touch(key)
+				}
+				m.touchIdle(key, entry)
+				entry.hits.Add(1)
+				result[key] = m.clone(val)
+				continue
+			}
+		}
+		missed[key] = true
+		misses = append(misses, key)
+	}
+	m.lock.RUnlock()
+	for key := range result {
+		m.recordAccess(key, true)
+	}
+	if len(misses) == 0 {
+		return result, nil
+	}
+	if m.batchLoader != nil {
+		for _, key := range misses {
+			m.recordAccess(key, false)
+		}
+		return result, m.batchLoad(misses, result)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mux  sync.Mutex
+		errs = make([]error, len(misses))
+	)
+	wg.Add(len(misses))
+	for i, key := range misses {
+		i, key := i, key
+		go func() {
+			defer wg.Done()
+			val, err := m.Get(key)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			result[key] = val
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return result, fmt.Errorf("error getting key '%v': %w", misses[i], err)
+		}
+	}
+	return result, nil
 }
 
 // MustGet does the same thing as Get, but it will panic if an error occurs.
@@ -66,48 +716,458 @@ func (m *MultiCache[K, V]) MustGet(key K) V {
 	return val
 }
 
-func (m *MultiCache[K, V]) populate(key K) {
+// batchLoad loads the given keys with the batch loader, and stores each loaded value in both the MultiCache and result.
+func (m *MultiCache[K, V]) batchLoad(keys []K, result map[K]V) error {
+	var missingErr error
+	if m.missing.Load() != nil {
+		found := keys[:0:0]
+		for _, key := range keys {
+			if !m.isMissing(key) {
+				found = append(found, key)
+			} else if missingErr == nil {
+				missingErr = &LoadError{Key: key, Err: missingFromBatch(key)}
+			}
+		}
+		if keys = found; len(keys) == 0 {
+			return missingErr
+		}
+	}
+	if shedder := m.shedder.Load(); shedder != nil {
+		if !shedder.tryAcquire() {
+			m.stats.loadsShed.Add(uint64(len(keys)))
+			return fmt.Errorf("error batch loading keys: %w", ErrOverloaded)
+		}
+		defer shedder.release()
+	}
+	start := time.Now()
+	loader := func() (map[K]V, error) {
+		return m.batchLoader(keys)
+	}
+	if timeout := time.Duration(m.loadTimeout.Load()); timeout > 0 {
+		load := loader
+		loader = timeoutLoader(context.Background(), timeout, func(context.Context) (map[K]V, error) {
+			return load()
+		})
+	}
+	if limiter := m.limiter.Load(); limiter != nil {
+		loader = limitLoader(context.Background(), limiter, loader)
+	}
+	vals, err := loader()
+	duration := time.Since(start)
+	m.stats.recordLoads(len(keys), err)
+	if events := m.hooks.Load().events; events != nil {
+		for _, key := range keys {
+			switch _, ok := vals[key]; {
+			case err != nil:
+				events.emit(Event{Type: EventLoadError, Key: key, Err: err, Duration: duration})
+			case !ok:
+				events.emit(Event{Type: EventLoadError, Key: key, Err: missingFromBatch(key), Duration: duration})
+			default:
+				events.emit(Event{Type: EventLoad, Key: key, Duration: duration})
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error batch loading keys: %w", err)
+	}
+	for _, key := range keys {
+		val, ok := vals[key]
+		if !ok {
+			m.addMissing(key)
+			m.stats.loadFailures.Add(1)
+			if missingErr == nil {
+				missingErr = &LoadError{Key: key, Err: missingFromBatch(key)}
+			}
+			continue
+		}
+		m.Set(key, val)
+		result[key] = m.clone(val)
+	}
+	return missingErr
+}
+
+// missingFromBatch returns the error for a key that the batch loader didn't return a value for.
+func missingFromBatch[K comparable](key K) error {
+	return fmt.Errorf("batch loader did not return a value for key '%v': %w", key, ErrKeyNotFound)
+}
+
+// populate returns the entry for key, and creates it if it doesn't exist yet, or is from an earlier generation.
+// The value for a new entry isn't loaded until it's requested.
+func (m *MultiCache[K, V]) populate(key K) *multiEntry[K, V] {
 	m.lock.Lock()
-	defer m.lock.Unlock()
-	if _, ok := m.values[key]; ok {
-		return
+	defer m.unlock()
+	if entry, ok := m.values[key]; ok {
+		if !m.isOutdated(entry) {
+			return entry
+		}
+		m.remove(key, ReasonGeneration)
 	}
 
 	if m.loader == nil {
 		panic("nil loader")
 	}
-	c := New[V](func() (V, error) {
-		key := key
-		return m.loader(key)
-	})
-	if m.ttl > 0 {
-		c.SetTTL(m.ttl)
+	var c *Value[V]
+	if m.ttlLoader != nil {
+		c = new(Value[V])
+		c.loadFunc = ttlLoaderFunc(c, func() (V, time.Duration, error) {
+			return m.ttlLoader(key)
+		})
+	} else {
+		c = New[V](func() (V, error) {
+			key := key
+			return m.loadKey(key)
+		})
+	}
+	if ttl := m.ttlFor(key); ttl > 0 {
+		c.SetTTL(ttl)
+		c.ttlJitter = m.ttlJitter
+	}
+	entry := &multiEntry[K, V]{value: c, weigher: m.weigher, generation: m.generation.Load()}
+	_, pinned := m.pinned[key]
+	entry.pinned.Store(pinned)
+	_, fixedTTL := m.keyTTLs[key]
+	entry.fixedTTL.Store(fixedTTL)
+	c.onLoad = func(prev *V, val V) {
+		// This is called while the Value's write lock is held, so MultiCache state that requires its lock is left pending for afterLoad.
+		entry.absentErr = nil
+		entry.loadedAt.Store(time.Now().UnixNano())
+		if entry.pinned.Load() {
+			// A loader that determines its own time to live can't expire a pinned value.
+			c.ttl = 0
+		}
+		if adaptive := m.adaptive.Load(); adaptive != nil && m.ttlLoader == nil && !entry.pinned.Load() && !entry.fixedTTL.Load() {
+			entry.adaptiveTTL = adaptive.next(entry.adaptiveTTL, c.ttl, prev, val)
+			c.ttl = entry.adaptiveTTL
+			c.expiration = time.Now().Add(jitter(c.ttl, c.ttlJitter))
+		}
+		if prev != nil && m.hooks.Load().onEvict != nil {
+			entry.mux.Lock()
+			entry.replaced = append(entry.replaced, *prev)
+			entry.mux.Unlock()
+		}
+		m.notifyWatchers(key, val)
+		if tagger := m.hooks.Load().tagger; tagger != nil {
+			tags := tagger(key, val)
+			entry.mux.Lock()
+			entry.pendingTags, entry.tagsPending = tags, true
+			entry.mux.Unlock()
+		}
+		if entry.weigher != nil {
+			entry.pendingCost.Store(entry.weigher(key, val))
+			entry.costPending.Store(true)
+		}
+		if c.ttl > 0 {
+			m.expirations.schedule(key, c.expiration)
+		}
+	}
+	if m.policy != nil && !pinned {
+		// Room is made before adding the new key, so it can't be chosen for eviction.
+		m.evictOverCapacity(1)
+		m.policy.add(key)
+	}
+	m.values[key] = entry
+	if m.indexKey != nil {
+		// A MultiCache2 indexes its keys by group.
+		m.indexKey(key, true)
+	}
+	return entry
+}
+
+// applyCost updates the total cost of the MultiCache with the pending cost of a newly loaded value, and evicts other values until the MultiCache is within its maximum cost.
+// The newly loaded value will not be evicted, even if it alone exceeds the maximum cost.
+func (m *MultiCache[K, V]) applyCost(key K, entry *multiEntry[K, V]) {
+	m.lock.Lock()
+	defer m.unlock()
+	if m.values[key] != entry || !entry.costPending.CompareAndSwap(true, false) {
+		return
+	}
+	cost := entry.pendingCost.Load()
+	m.totalCost += cost - entry.cost
+	entry.cost = cost
+	if m.policy == nil || m.maxCost <= 0 {
+		return
+	}
+	for m.totalCost > m.maxCost {
+		victim, ok := m.policy.victimExcept(key)
+		if !ok {
+			return
+		}
+		m.remove(victim, ReasonCost)
+	}
+}
+
+// remove drops the value for key from the MultiCache, along with any bookkeeping for it.
+// Handlers for the removal will be called by unlock.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) remove(key K, reason Reason) bool {
+	entry, ok := m.values[key]
+	if !ok {
+		return false
+	}
+	delete(m.values, key)
+	if m.indexKey != nil {
+		m.indexKey(key, false)
 	}
-	m.values[key] = c
+	switch reason {
+	case ReasonExpired, ReasonCapacity, ReasonCost, ReasonIdle:
+		m.stats.evictions.Add(1)
+	}
+	m.totalCost -= entry.cost
+	m.untag(key, entry)
+	m.expirations.unschedule(key)
+	m.idle.unschedule(key)
+	if m.policy != nil {
+		m.policy.remove(key)
+	}
+	m.removed = append(m.removed, removal[K, V]{key: key, entry: entry, reason: reason})
+	return true
+}
+
+// unlock releases the write lock, and then finishes any removals made while it was held.
+// Invalidated values are invalidated, and registered handlers are called, without holding the MultiCache's lock.
+// This means that neither a Value with a load in progress nor a handler can block other requests to the MultiCache.
+func (m *MultiCache[K, V]) unlock() {
+	removed := m.removed
+	m.removed = nil
+	m.lock.Unlock()
+	if len(removed) == 0 {
+		return
+	}
+	hooks := m.hooks.Load()
+	for _, r := range removed {
+		if hooks.onEvict != nil {
+			if val, ok := r.entry.value.current(); ok {
+				hooks.onEvict(r.key, val, r.reason)
+			}
+		}
+		if r.reason != ReasonInvalidated {
+			hooks.events.emit(Event{Type: EventEvict, Key: r.key, Reason: r.reason})
+			continue
+		}
+		hooks.events.emit(Event{Type: EventInvalidate, Key: r.key, Reason: r.reason})
+		r.entry.value.Invalidate()
+		if hooks.onAnyInvalidate != nil {
+			hooks.onAnyInvalidate(r.key)
+		}
+		for _, child := range hooks.children {
+			child.Invalidate(r.key)
+		}
+	}
+}
+
+// evictOverCapacity removes values chosen by the eviction policy until the MultiCache has room for reserve more values within its configured capacity.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) evictOverCapacity(reserve int) {
+	if m.policy == nil || m.maxEntries <= 0 {
+		return
+	}
+	for len(m.values)+reserve > m.maxEntries {
+		key, ok := m.policy.victim()
+		if !ok {
+			return
+		}
+		m.remove(key, ReasonCapacity)
+	}
+}
+
+// Set will cache val for key without calling the loader, replacing any existing value.
+// The value's time to live is reset as if it had just been loaded.
+// This is useful for write-through patterns, where the caller has just persisted val and already knows it's the current value.
+func (m *MultiCache[K, V]) Set(key K, val V) {
+	key = m.normalize(key)
+	m.forgetMissing(key)
+	entry := m.populate(key)
+	m.touchIdle(key, entry)
+	entry.value.store(val)
+	m.afterLoad(key, entry)
+}
+
+// GetOrSet will return the existing value for key and true if a valid value is cached.
+// Otherwise, val will be cached as if it were set with Set, and returned with false.
+// The loader is never called.
+//
+// This is useful when a value is computed opportunistically, and concurrent callers should all adopt whichever value was cached first.
+func (m *MultiCache[K, V]) GetOrSet(key K, val V) (actual V, loaded bool) {
+	key = m.normalize(key)
+	m.forgetMissing(key)
+	entry := m.populate(key)
+	m.lock.RLock()
+	if m.policy != nil {
+		m.policy.touch(key)
+	}
+	m.lock.RUnlock()
+	m.touchIdle(key, entry)
+	actual, loaded = entry.value.getOrStore(val)
+	m.afterLoad(key, entry)
+	return m.clone(actual), loaded
 }
 
 // Invalidate will invalidate the cache.Value related to key K, if it exists.
+// The value for key will also be invalidated in any child caches created with [Child], even if it isn't held by this MultiCache.
 func (m *MultiCache[K, V]) Invalidate(key K) {
+	key = m.normalize(key)
+	m.forgetMissing(key)
 	m.lock.Lock()
-	defer m.lock.Unlock()
-	c, ok := m.values[key]
+	removed := m.remove(key, ReasonInvalidated)
+	m.unlock()
+	if !removed {
+		for _, child := range m.hooks.Load().children {
+			child.Invalidate(key)
+		}
+	}
+}
+
+// Remove will delete the value for key from the MultiCache, and return the value that was cached and true if there was one.
+// The returned value may have expired, but it's still reported since it's what was held by the MultiCache.
+// This is useful for write-back workflows, where a removed value may still need to be persisted.
+//
+// Unlike Invalidate, this will not call any OnInvalidateFunc or OnAnyInvalidate handler, and will not affect child caches.
+// An OnEvict handler will be called with [ReasonRemoved].
+func (m *MultiCache[K, V]) Remove(key K) (V, bool) {
+	key = m.normalize(key)
+	m.lock.Lock()
+	entry, ok := m.values[key]
+	if ok {
+		m.remove(key, ReasonRemoved)
+	}
+	m.unlock()
 	if !ok {
-		return
+		var mt V
+		return mt, false
 	}
-	c.Invalidate()
-	delete(m.values, key)
+	val, ok := entry.value.current()
+	if !ok {
+		return val, false
+	}
+	return m.clone(val), true
+}
+
+// InvalidateWhere will invalidate every valid value in the MultiCache for which the predicate returns true, and returns the number of values invalidated.
+// This is useful for invalidating a group of related values without tracking their keys, such as all values belonging to a tenant.
+//
+// The predicate is called without holding the MultiCache's lock, in the same way as ForEach.
+func (m *MultiCache[K, V]) InvalidateWhere(predicate func(key K, val V) bool) int {
+	var matched []K
+	m.ForEach(func(key K, val V) bool {
+		if predicate(key, val) {
+			matched = append(matched, key)
+		}
+		return true
+	})
+	if len(matched) == 0 {
+		return 0
+	}
+
+	m.lock.Lock()
+	defer m.unlock()
+	var invalidated int
+	for _, key := range matched {
+		if m.remove(key, ReasonInvalidated) {
+			invalidated++
+		}
+	}
+	return invalidated
+}
+
+// InvalidatePrefix will invalidate every value in a string keyed MultiCache where the key starts with prefix, and returns the number of values invalidated.
+// This is useful for flushing hierarchical keys as a group, such as every key starting with "user:42:".
+//
+// This is a function rather than a method, since it only applies to a MultiCache with string keys.
+func InvalidatePrefix[V any](m *MultiCache[string, V], prefix string) int {
+	m.lock.Lock()
+	defer m.unlock()
+	var matched []string
+	for key := range m.values {
+		if strings.HasPrefix(key, prefix) {
+			matched = append(matched, key)
+		}
+	}
+	for _, key := range matched {
+		m.remove(key, ReasonInvalidated)
+	}
+	return len(matched)
 }
 
 // OnInvalidate sets an OnInvalidateFunc on the Value referenced by key.
 // If no Value is associated to the given key, then no action is taken.
 func (m *MultiCache[K, V]) OnInvalidate(key K, invalidateFunc OnInvalidateFunc) {
-	m.lock.Lock()
-	defer m.lock.Unlock()
-	c, ok := m.values[key]
+	key = m.normalize(key)
+	m.lock.RLock()
+	entry, ok := m.values[key]
+	m.lock.RUnlock()
 	if !ok {
 		return
 	}
-	c.OnInvalidate(invalidateFunc)
+	entry.value.OnInvalidate(invalidateFunc)
+}
+
+// OnAnyInvalidate sets a function that will be called with the key of every value that's invalidated in the MultiCache.
+// Unlike OnInvalidate, this only needs to be registered once, and applies to keys that are added to the MultiCache later.
+// The function is called after any OnInvalidateFunc set for the key.
+//
+// Note that the given function is only called when a value is invalidated, not when it expires or is evicted.
+// The function is called without holding the MultiCache's lock.
+func (m *MultiCache[K, V]) OnAnyInvalidate(fn OnInvalidateKeyFunc[K]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.onAnyInvalidate = fn
+	})
+}
+
+// OnEvict sets a function that will be called with the key and value of every value removed from the MultiCache, along with the [Reason] for its removal.
+// This is useful for releasing resources held by cached values, such as file handles or connections.
+// The function is also called when a cached value is replaced by a newly loaded or set value, since the previous value is no longer reachable.
+//
+// The function is called without holding the MultiCache's lock.
+func (m *MultiCache[K, V]) OnEvict(fn OnEvictFunc[K, V]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.onEvict = fn
+	})
+}
+
+// OnHit sets a function that will be called with the key of every request that's served by a valid cached value.
+// Along with OnMiss, this is useful for logging or counting cache effectiveness without wrapping every call to Get.
+//
+// The function is called without holding the MultiCache's lock.
+func (m *MultiCache[K, V]) OnHit(fn OnAccessFunc[K]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.onHit = fn
+	})
+}
+
+// OnMiss sets a function that will be called with the key of every request that isn't served by a valid cached value, which usually results in a load.
+// See OnHit for more details.
+func (m *MultiCache[K, V]) OnMiss(fn OnAccessFunc[K]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.onMiss = fn
+	})
+}
+
+// SetCloner sets a function that will be used to copy each value returned from the MultiCache.
+// This gives each caller its own copy of values that contain slices, maps, or pointers, so a caller modifying its value can't affect other goroutines sharing the MultiCache.
+// The cloner is applied to values returned by Get, GetCtx, GetIfPresent, GetMulti, GetOrSet, and ForEach.
+//
+// Note that values passed to Set are cached as-is, so callers should not modify a value after setting it.
+func (m *MultiCache[K, V]) SetCloner(cloner func(val V) V) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.cloner = cloner
+	})
+}
+
+// clone returns a copy of val made with the MultiCache's cloner, or val if no cloner is set.
+func (m *MultiCache[K, V]) clone(val V) V {
+	if cloner := m.hooks.Load().cloner; cloner != nil {
+		return cloner(val)
+	}
+	return val
+}
+
+// updateHooks replaces the MultiCache's handlers with a modified copy.
+func (m *MultiCache[K, V]) updateHooks(update func(hooks *multiHooks[K, V])) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	hooks := *m.hooks.Load()
+	update(&hooks)
+	m.hooks.Store(&hooks)
 }
 
 // SetTTLPolicy sets the time to live policy for all internal Value values after they are retrieved.
@@ -118,10 +1178,375 @@ func (m *MultiCache[K, V]) OnInvalidate(key K, invalidateFunc OnInvalidateFunc)
 //
 // This method will panic if ttl <= 0.
 func (m *MultiCache[K, V]) SetTTLPolicy(ttl time.Duration) {
+	m.setTTLPolicy(ttl, 0)
+}
+
+// SetTTLPolicyJitter is the same as SetTTLPolicy, except that each loaded value's time to live will randomly vary by up to ±jitterFraction of ttl.
+// Without jitter, values that are loaded together, such as with Preheat, will also expire together and cause a burst of loads.
+// For example, a ttl of 10 minutes with a jitterFraction of 0.1 will give each value a time to live between 9 and 11 minutes.
+//
+// This method will panic if ttl <= 0, or if jitterFraction is not in the range [0, 1).
+func (m *MultiCache[K, V]) SetTTLPolicyJitter(ttl time.Duration, jitterFraction float64) {
+	if jitterFraction < 0 || jitterFraction >= 1 {
+		panic("jitter fraction must be in the range [0, 1)")
+	}
+	m.setTTLPolicy(ttl, jitterFraction)
+}
+
+func (m *MultiCache[K, V]) setTTLPolicy(ttl time.Duration, jitterFraction float64) {
+	if ttl <= 0 {
+		panic("ttl <= 0")
+	}
 	m.lock.Lock()
 	defer m.lock.Unlock()
+	m.ttl = ttl
+	m.ttlJitter = jitterFraction
+}
+
+// SetNegativeTTL enables caching of absent keys for ttl.
+// When the loader returns [ErrKeyNotFound], or an error for which isAbsent returns true, such as a "not found" error from a database, that error will be returned for the key without calling the loader again until ttl has elapsed.
+// This protects the underlying data source from repeated requests for keys that will never exist.
+// Other errors are never cached, and setting a value for the key with Set will clear a cached absence.
+//
+// This method will panic if ttl <= 0 or isAbsent is nil.
+func (m *MultiCache[K, V]) SetNegativeTTL(ttl time.Duration, isAbsent func(err error) bool) {
 	if ttl <= 0 {
 		panic("ttl <= 0")
 	}
-	m.ttl = ttl
+	if isAbsent == nil {
+		panic("nil isAbsent")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.negativeTTL = ttl
+	m.isAbsent = isAbsent
+}
+
+// SetKeyTTL sets the time to live for the value associated with key, overriding the policy set with SetTTLPolicy.
+// This is useful when some values should be refreshed more often than others, such as caching sessions for admin users for a shorter time than anonymous sessions.
+// The override applies to the currently cached value, if any, and to any value loaded for key in the future, until RemoveKeyTTL is called.
+//
+// This method will panic if ttl <= 0.
+func (m *MultiCache[K, V]) SetKeyTTL(key K, ttl time.Duration) {
+	if ttl <= 0 {
+		panic("ttl <= 0")
+	}
+	key = m.normalize(key)
+	m.lock.Lock()
+	m.keyTTLs[key] = ttl
+	ttl = m.ttlFor(key)
+	entry, ok := m.values[key]
+	if ok {
+		entry.fixedTTL.Store(true)
+	}
+	m.lock.Unlock()
+	if ok {
+		m.applyTTL(key, entry, ttl)
+	}
+}
+
+// RemoveKeyTTL removes a time to live override set with SetKeyTTL, so the value associated with key will use the MultiCache's TTL policy again.
+func (m *MultiCache[K, V]) RemoveKeyTTL(key K) {
+	key = m.normalize(key)
+	m.lock.Lock()
+	if _, ok := m.keyTTLs[key]; !ok {
+		m.lock.Unlock()
+		return
+	}
+	delete(m.keyTTLs, key)
+	ttl := m.ttlFor(key)
+	entry, ok := m.values[key]
+	if ok {
+		entry.fixedTTL.Store(false)
+	}
+	m.lock.Unlock()
+	if ok {
+		m.applyTTL(key, entry, ttl)
+	}
+}
+
+// ExpiresAt returns the time that the value for key will expire, and true if a valid value is cached.
+// If the value has no time to live, such as when it's pinned, then the zero time is returned with true.
+// This allows callers to decide whether to refresh, extend, or bypass a value based on how long it will remain valid.
+// Like GetIfPresent, this will never call the loader, but it isn't counted as an access of the value.
+func (m *MultiCache[K, V]) ExpiresAt(key K) (time.Time, bool) {
+	key = m.normalize(key)
+	m.lock.RLock()
+	entry, ok := m.values[key]
+	m.lock.RUnlock()
+	if !ok || m.isIdle(entry) || m.isOutdated(entry) {
+		return time.Time{}, false
+	}
+	_, at, ok := entry.value.cachedUntil()
+	return at, ok
+}
+
+// TTLRemaining returns the time remaining until the value for key expires, and true if a valid value is cached.
+// If the value has no time to live, then 0 is returned with true.
+// See ExpiresAt for details.
+func (m *MultiCache[K, V]) TTLRemaining(key K) (time.Duration, bool) {
+	at, ok := m.ExpiresAt(key)
+	if !ok || at.IsZero() {
+		return 0, ok
+	}
+	remaining := time.Until(at)
+	if remaining <= 0 {
+		// Expired since ExpiresAt was called.
+		return 0, false
+	}
+	return remaining, true
+}
+
+// ttlFor returns the time to live that should be used for the value associated with key.
+// This must be called while holding a read or write lock.
+func (m *MultiCache[K, V]) ttlFor(key K) time.Duration {
+	if _, ok := m.pinned[key]; ok {
+		return 0
+	}
+	if ttl, ok := m.keyTTLs[key]; ok {
+		return ttl
+	}
+	return m.ttl
+}
+
+// applyTTL updates the time to live of the entry's Value, and reschedules its expiration.
+// This must be called without holding a lock, since the Value may be loading.
+func (m *MultiCache[K, V]) applyTTL(key K, entry *multiEntry[K, V], ttl time.Duration) {
+	if ttl > 0 {
+		entry.value.SetTTL(ttl)
+	} else {
+		entry.value.RemoveTTL()
+	}
+	if at, ok := entry.value.expiresAt(); ok {
+		m.expirations.schedule(key, at)
+	} else {
+		m.expirations.unschedule(key)
+	}
+}
+
+// SetMaxEntries limits the number of values held in the MultiCache to n.
+// When a new key is added and the MultiCache would exceed this limit, values will be evicted according to the MultiCache's [EvictionPolicy].
+// By default, the least recently used values will be evicted, but this may be changed with SetEvictionPolicy.
+// An evicted value is simply dropped, its OnInvalidateFunc will not be called, and it will be loaded again the next time its key is requested.
+// This makes it safe to key a MultiCache with user-supplied values, since the MultiCache can no longer grow without bound.
+//
+// If the MultiCache already holds more than n values, then values will be evicted immediately.
+// This method will panic if n <= 0.
+func (m *MultiCache[K, V]) SetMaxEntries(n int) {
+	if n <= 0 {
+		panic("max entries <= 0")
+	}
+	m.lock.Lock()
+	defer m.unlock()
+	m.maxEntries = n
+	if m.policy == nil {
+		m.resetEvictor()
+	}
+	m.evictOverCapacity(0)
+}
+
+// SetEvictionPolicy sets the [EvictionPolicy] used to select values for eviction when the MultiCache is over capacity.
+// Any access history gathered under the previous policy is discarded.
+// This has no effect on a MultiCache until SetMaxEntries is used to set a capacity.
+//
+// This method will panic if policy is not a known EvictionPolicy.
+func (m *MultiCache[K, V]) SetEvictionPolicy(policy EvictionPolicy) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if policy != EvictLRU && policy != EvictLFU {
+		panic(fmt.Sprintf("unknown eviction policy: %s", policy))
+	}
+	m.evictionPolicy = policy
+	if m.policy != nil {
+		m.resetEvictor()
+	}
+}
+
+// resetEvictor creates a new evictor for the configured EvictionPolicy and tracks all current keys with it.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) resetEvictor() {
+	m.policy = newEvictor[K](m.evictionPolicy)
+	for key := range m.values {
+		if _, ok := m.pinned[key]; !ok {
+			m.policy.add(key)
+		}
+	}
+}
+
+// SetMaxCost limits the total cost of values held in the MultiCache to maxCost, as calculated by the given [Weigher].
+// This is useful when cached values vary greatly in size, and limiting the number of entries with SetMaxEntries isn't enough to bound memory usage.
+// When a newly loaded value causes the MultiCache to exceed this limit, other values will be evicted according to the MultiCache's [EvictionPolicy].
+// A value that exceeds maxCost by itself will still be cached until another value is loaded.
+//
+// Values that are already cached will be weighed immediately, and evicted if needed.
+// This method will panic if maxCost <= 0 or weigher is nil.
+func (m *MultiCache[K, V]) SetMaxCost(maxCost int64, weigher Weigher[K, V]) {
+	if maxCost <= 0 {
+		panic("max cost <= 0")
+	}
+	if weigher == nil {
+		panic("nil weigher")
+	}
+	m.lock.Lock()
+	m.maxCost = maxCost
+	m.weigher = weigher
+	if m.policy == nil {
+		m.resetEvictor()
+	}
+	entries := make(map[K]*multiEntry[K, V], len(m.values))
+	for key, entry := range m.values {
+		entries[key] = entry
+	}
+	m.unlock()
+
+	// Existing values are weighed as if they were just loaded, without holding the MultiCache's lock.
+	for key, entry := range entries {
+		entry.value.mux.Lock()
+		entry.weigher = weigher
+		var cost int64
+		if entry.value.val != nil {
+			cost = weigher(key, *entry.value.val)
+		}
+		entry.pendingCost.Store(cost)
+		entry.costPending.Store(true)
+		entry.value.mux.Unlock()
+		m.applyCost(key, entry)
+	}
+}
+
+// RemoveExpired will remove all values from the MultiCache that have passed their expiration time, and returns the number of values removed.
+// Values that have been idle for longer than the time set with SetMaxIdle, or were cached before the last call to BumpGeneration, are also removed.
+// Expired values are normally reloaded the next time their key is requested, so this is only needed to release memory held by values that are no longer requested.
+// Removed values are simply dropped, and their OnInvalidateFunc will not be called.
+//
+// Expirations are indexed as values are loaded, so this only needs to inspect values that are actually expired, rather than every value in the MultiCache.
+func (m *MultiCache[K, V]) RemoveExpired() int {
+	m.lock.Lock()
+	defer m.unlock()
+	var (
+		now     = time.Now()
+		removed int
+	)
+	for _, item := range m.expirations.popDue(now) {
+		entry, ok := m.values[item.key]
+		if !ok {
+			continue
+		}
+		actual, ok, acquired := entry.value.tryExpiresAt()
+		switch {
+		case !acquired:
+			// The Value is busy, likely reloading, so it's checked again later rather than blocking the MultiCache.
+			m.expirations.schedule(item.key, item.at)
+		case !ok:
+			// No longer set to expire.
+		case actual.After(now):
+			// The expiration was refreshed after it was scheduled.
+			m.expirations.schedule(item.key, actual)
+		case actual.Add(m.maxStale).After(now):
+			// The value may still be served while it's revalidated.
+			m.expirations.schedule(item.key, actual.Add(m.maxStale))
+		default:
+			m.remove(item.key, ReasonExpired)
+			removed++
+		}
+	}
+	return removed + m.removeIdleLocked(now) + m.removeOutdatedLocked()
+}
+
+// NextExpiration returns the soonest time that a value in the MultiCache will expire, or false if no values are set to expire.
+// The returned time may be in the past if a value has expired, but hasn't been reloaded or removed with RemoveExpired.
+func (m *MultiCache[K, V]) NextExpiration() (time.Time, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for {
+		key, at, ok := m.expirations.next()
+		if !ok {
+			return time.Time{}, false
+		}
+		entry, ok := m.values[key]
+		if !ok {
+			m.expirations.unschedule(key)
+			continue
+		}
+		actual, ok, acquired := entry.value.tryExpiresAt()
+		switch {
+		case !acquired:
+			// The Value is busy, so the scheduled time is the best available answer.
+			return at, true
+		case !ok:
+			m.expirations.unschedule(key)
+		case !actual.Equal(at):
+			m.expirations.schedule(key, actual)
+		default:
+			return at, true
+		}
+	}
+}
+
+// Stats returns a snapshot of the MultiCache's aggregate counters, such as hits, misses, and loads.
+// This is useful for building dashboards without instrumenting every call site with OnHit and OnMiss.
+func (m *MultiCache[K, V]) Stats() Stats {
+	stats := m.stats.snapshot()
+	stats.Entries = m.Len()
+	return stats
+}
+
+// Len returns the number of valid values currently held in the MultiCache.
+func (m *MultiCache[K, V]) Len() int {
+	var n int
+	m.ForEach(func(_ K, _ V) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Keys returns the keys of all valid values currently held in the MultiCache, in no particular order.
+// This will not load or refresh any values.
+func (m *MultiCache[K, V]) Keys() []K {
+	var keys []K
+	m.ForEach(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ForEach calls fn with each valid key and value currently held in the MultiCache, in no particular order.
+// Iteration will stop if fn returns false.
+// This will not load or refresh any values, and values that are expired, failed to load, or are currently being loaded are skipped.
+//
+// The MultiCache is not locked while fn is called, so it's safe to call other MultiCache methods from fn.
+// Changes made during iteration may or may not be observed.
+func (m *MultiCache[K, V]) ForEach(fn func(key K, val V) bool) {
+	for _, ke := range m.entries() {
+		// A slow load for one key shouldn't hold up iteration over the others.
+		val, ok := ke.entry.value.tryCached()
+		if !ok {
+			continue
+		}
+		if !fn(ke.key, m.clone(val)) {
+			return
+		}
+	}
+}
+
+// keyedEntry pairs a multiEntry with its key.
+type keyedEntry[K comparable, V any] struct {
+	key   K
+	entry *multiEntry[K, V]
+}
+
+// entries returns a snapshot of every entry in the MultiCache, so they may be used without holding the lock.
+func (m *MultiCache[K, V]) entries() []keyedEntry[K, V] {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	entries := make([]keyedEntry[K, V], 0, len(m.values))
+	for key, entry := range m.values {
+		if m.isOutdated(entry) {
+			continue
+		}
+		entries = append(entries, keyedEntry[K, V]{key: key, entry: entry})
+	}
+	return entries
 }
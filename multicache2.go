@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// GroupKey is the key of a value in a [MultiCache2], made up of the group it belongs to and its key within that group.
+type GroupKey[K1 comparable, K2 comparable] struct {
+	Group K1
+	Key   K2
+}
+
+// MultiLoader2Func is a lot like MultiLoaderFunc, except that it accepts the group and key of the value to load.
+type MultiLoader2Func[K1 comparable, K2 comparable, V any] func(group K1, key K2) (V, error)
+
+// MultiCache2 caches values by two levels of keys, such as a tenant and an entity ID within that tenant.
+// Each value belongs to a group identified by its first-level key, and every value in a group may be invalidated at once with InvalidateGroup.
+//
+// A MultiCache2 is backed by a single [MultiCache] keyed by [GroupKey], so limits such as SetMaxEntries apply across all groups.
+// Configuration that isn't exposed by MultiCache2 may be set on the MultiCache returned by MultiCache.
+type MultiCache2[K1 comparable, K2 comparable, V any] struct {
+	cache *MultiCache[GroupKey[K1, K2], V]
+	// groups indexes the keys held by cache by group, and is guarded by cache's lock.
+	groups map[K1]map[K2]struct{}
+}
+
+// NewMulti2 will create a new MultiCache2 with the given loader.
+// If the loader is nil, then this function will panic.
+func NewMulti2[K1 comparable, K2 comparable, V any](loader MultiLoader2Func[K1, K2, V]) *MultiCache2[K1, K2, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	m := &MultiCache2[K1, K2, V]{
+		cache: NewMulti[GroupKey[K1, K2], V](func(key GroupKey[K1, K2]) (V, error) {
+			return loader(key.Group, key.Key)
+		}),
+		groups: map[K1]map[K2]struct{}{},
+	}
+	m.cache.indexKey = m.indexKey
+	return m
+}
+
+// indexKey adds or removes key from the group index.
+// This must be called while holding the write lock of the MultiCache.
+func (m *MultiCache2[K1, K2, V]) indexKey(key GroupKey[K1, K2], added bool) {
+	keys, ok := m.groups[key.Group]
+	if added {
+		if !ok {
+			keys = map[K2]struct{}{}
+			m.groups[key.Group] = keys
+		}
+		keys[key.Key] = struct{}{}
+		return
+	}
+	delete(keys, key.Key)
+	if ok && len(keys) == 0 {
+		delete(m.groups, key.Group)
+	}
+}
+
+// MultiCache returns the MultiCache that holds the values of the MultiCache2, which may be used to configure it further.
+func (m *MultiCache2[K1, K2, V]) MultiCache() *MultiCache[GroupKey[K1, K2], V] {
+	return m.cache
+}
+
+// Get will return the value for key in group, and load it if it isn't cached.
+// See [MultiCache.Get] for details.
+func (m *MultiCache2[K1, K2, V]) Get(group K1, key K2) (V, error) {
+	return m.cache.Get(GroupKey[K1, K2]{Group: group, Key: key})
+}
+
+// GetCtx is the same as Get, except that ctx may be used to cancel waiting for the load.
+// See [MultiCache.GetCtx] for details.
+func (m *MultiCache2[K1, K2, V]) GetCtx(ctx context.Context, group K1, key K2) (V, error) {
+	return m.cache.GetCtx(ctx, GroupKey[K1, K2]{Group: group, Key: key})
+}
+
+// MustGet does the same thing as Get, but it will panic if an error occurs.
+func (m *MultiCache2[K1, K2, V]) MustGet(group K1, key K2) V {
+	return m.cache.MustGet(GroupKey[K1, K2]{Group: group, Key: key})
+}
+
+// GetIfPresent will return the value for key in group and true if a valid value is cached, without loading it.
+// See [MultiCache.GetIfPresent] for details.
+func (m *MultiCache2[K1, K2, V]) GetIfPresent(group K1, key K2) (V, bool) {
+	return m.cache.GetIfPresent(GroupKey[K1, K2]{Group: group, Key: key})
+}
+
+// Set will cache val for key in group without calling the loader, replacing any existing value.
+// See [MultiCache.Set] for details.
+func (m *MultiCache2[K1, K2, V]) Set(group K1, key K2, val V) {
+	m.cache.Set(GroupKey[K1, K2]{Group: group, Key: key}, val)
+}
+
+// Invalidate will invalidate the value for key in group, if it exists.
+// See [MultiCache.Invalidate] for details.
+func (m *MultiCache2[K1, K2, V]) Invalidate(group K1, key K2) {
+	m.cache.Invalidate(GroupKey[K1, K2]{Group: group, Key: key})
+}
+
+// InvalidateGroup will invalidate every value in group, and returns the number of values invalidated.
+// This is useful when everything known about a group is outdated, such as when a tenant's data is migrated.
+// Values in the group that are being loaded are also invalidated, so they will be loaded again the next time they're requested.
+//
+// The whole group is invalidated while holding the write lock, so no value in the group can be read after InvalidateGroup returns.
+// Any OnInvalidateFunc or OnAnyInvalidate handlers are called as if each value were invalidated with Invalidate.
+// Keys are indexed by group, so the cost of InvalidateGroup depends on the size of the group rather than the size of the cache.
+func (m *MultiCache2[K1, K2, V]) InvalidateGroup(group K1) int {
+	c := m.cache
+	var invalidated int
+	c.lock.Lock()
+	// remove updates the index, so the group's keys are copied first.
+	keys := make([]K2, 0, len(m.groups[group]))
+	for key := range m.groups[group] {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if c.remove(GroupKey[K1, K2]{Group: group, Key: key}, ReasonInvalidated) {
+			invalidated++
+		}
+	}
+	c.unlock()
+	if filter := c.missing.Load(); filter != nil {
+		// Keys in the group that were missing may exist now.
+		c.missing.CompareAndSwap(filter, filter.empty())
+	}
+	return invalidated
+}
+
+// GroupKeys returns the second-level key of each valid value in group, in no particular order.
+// Like InvalidateGroup, the cost of GroupKeys depends on the size of the group rather than the size of the cache.
+func (m *MultiCache2[K1, K2, V]) GroupKeys(group K1) []K2 {
+	c := m.cache
+	type groupEntry struct {
+		key   K2
+		entry *multiEntry[GroupKey[K1, K2], V]
+	}
+	c.lock.RLock()
+	entries := make([]groupEntry, 0, len(m.groups[group]))
+	for key := range m.groups[group] {
+		entry := c.values[GroupKey[K1, K2]{Group: group, Key: key}]
+		if c.isOutdated(entry) {
+			continue
+		}
+		entries = append(entries, groupEntry{key: key, entry: entry})
+	}
+	c.lock.RUnlock()
+
+	var keys []K2
+	for _, ge := range entries {
+		// A slow load for one key shouldn't hold up the others, the same as ForEach.
+		if _, ok := ge.entry.value.tryCached(); ok {
+			keys = append(keys, ge.key)
+		}
+	}
+	return keys
+}
+
+// SetTTLPolicy sets the time to live of newly loaded values in every group.
+// See [MultiCache.SetTTLPolicy] for details.
+//
+// This method will panic if ttl <= 0.
+func (m *MultiCache2[K1, K2, V]) SetTTLPolicy(ttl time.Duration) {
+	m.cache.SetTTLPolicy(ttl)
+}
+
+// SetMaxEntries limits the number of values held across all groups to n.
+// See [MultiCache.SetMaxEntries] for details.
+//
+// This method will panic if n <= 0.
+func (m *MultiCache2[K1, K2, V]) SetMaxEntries(n int) {
+	m.cache.SetMaxEntries(n)
+}
+
+// Len returns the number of valid values held across all groups.
+func (m *MultiCache2[K1, K2, V]) Len() int {
+	return m.cache.Len()
+}
+
+// Stats returns a snapshot of the MultiCache2's counters, across all groups.
+// See [MultiCache.Stats] for details.
+func (m *MultiCache2[K1, K2, V]) Stats() Stats {
+	return m.cache.Stats()
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache2(t *testing.T) {
+	var loads atomic.Int32
+	mc := NewMulti2[string, int, string](func(tenant string, id int) (string, error) {
+		loads.Add(1)
+		return fmt.Sprintf("%s/%d", tenant, id), nil
+	})
+
+	assert.Equal(t, "a/1", mc.MustGet("a", 1))
+	assert.Equal(t, "a/2", mc.MustGet("a", 2))
+	assert.Equal(t, "b/1", mc.MustGet("b", 1))
+	assert.Equal(t, "a/1", mc.MustGet("a", 1))
+	assert.Equal(t, int32(3), loads.Load())
+	assert.Equal(t, 3, mc.Len())
+
+	keys := mc.GroupKeys("a")
+	sort.Ints(keys)
+	assert.Equal(t, []int{1, 2}, keys)
+
+	var invalidated []GroupKey[string, int]
+	mc.MultiCache().OnAnyInvalidate(func(key GroupKey[string, int]) {
+		invalidated = append(invalidated, key)
+	})
+	assert.Equal(t, 2, mc.InvalidateGroup("a"))
+	assert.Len(t, invalidated, 2)
+	_, ok := mc.GetIfPresent("a", 1)
+	assert.False(t, ok)
+	val, ok := mc.GetIfPresent("b", 1)
+	assert.True(t, ok)
+	assert.Equal(t, "b/1", val)
+	assert.Equal(t, 0, mc.InvalidateGroup("c"))
+
+	mc.Set("c", 5, "set")
+	assert.Equal(t, "set", mc.MustGet("c", 5))
+	mc.Invalidate("c", 5)
+	assert.Equal(t, "c/5", mc.MustGet("c", 5))
+
+	assert.Panics(t, func() {
+		NewMulti2[string, int, string](nil)
+	})
+}
+
+func TestMultiCache2_GroupIndex(t *testing.T) {
+	mc := NewMulti2[string, int, int](func(_ string, id int) (int, error) {
+		return id, nil
+	})
+	for _, group := range []string{"a", "b", "c"} {
+		for i := 0; i < 10; i++ {
+			mc.MustGet(group, i)
+		}
+	}
+
+	mc.Invalidate("a", 0)
+	keys := mc.GroupKeys("a")
+	sort.Ints(keys)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+	assert.Len(t, mc.groups["a"], 9)
+
+	var invalidated []GroupKey[string, int]
+	mc.MultiCache().OnAnyInvalidate(func(key GroupKey[string, int]) {
+		invalidated = append(invalidated, key)
+	})
+	assert.Equal(t, 10, mc.InvalidateGroup("b"))
+	assert.Len(t, invalidated, 10)
+	for _, key := range invalidated {
+		assert.Equal(t, "b", key.Group)
+	}
+	assert.Empty(t, mc.GroupKeys("b"))
+	assert.NotContains(t, mc.groups, "b", "An empty group should be dropped from the index")
+	assert.Len(t, mc.GroupKeys("c"), 10)
+	assert.Equal(t, 19, mc.Len())
+
+	// Evicted keys should be dropped from the index too.
+	mc.SetMaxEntries(5)
+	var indexed int
+	for _, keys := range mc.groups {
+		indexed += len(keys)
+	}
+	assert.Equal(t, mc.Len(), indexed)
+	assert.Equal(t, 5, len(mc.GroupKeys("a"))+len(mc.GroupKeys("c")))
+}
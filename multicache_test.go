@@ -1,9 +1,13 @@
 package cache
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -209,3 +213,1004 @@ func TestMultiCache_Preheat(t *testing.T) {
 	_, _ = mc.Get("erin")
 	assert.Equal(t, 3, timesFetched, "The records are already fetched and valid, so they should have been returned from cache")
 }
+
+func TestMultiCache_SetMaxEntries(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		timesFetched++
+		return key * 2, nil
+	})
+	mc.SetMaxEntries(2)
+
+	assert.Equal(t, 2, mc.MustGet(1))
+	assert.Equal(t, 4, mc.MustGet(2))
+	assert.Equal(t, 2, timesFetched)
+
+	_, _ = mc.Get(1)
+	assert.Equal(t, 2, timesFetched, "Key 1 should still be cached")
+	assert.Equal(t, 6, mc.MustGet(3))
+	assert.Equal(t, 3, timesFetched)
+	assert.Len(t, mc.values, 2, "Only 2 entries should be held")
+
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(3)
+	assert.Equal(t, 3, timesFetched, "Keys 1 and 3 should still be cached")
+	_, _ = mc.Get(2)
+	assert.Equal(t, 4, timesFetched, "Key 2 should have been evicted as the least recently used key")
+}
+
+func TestMultiCache_SetMaxEntries_Shrink(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3, 4, 5}))
+	assert.Len(t, mc.values, 5)
+	mc.SetMaxEntries(3)
+	assert.Len(t, mc.values, 3, "Existing values over the limit should be evicted immediately")
+	assert.Panics(t, func() {
+		mc.SetMaxEntries(0)
+	})
+}
+
+func TestMultiCache_SetEvictionPolicy(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		timesFetched++
+		return key, nil
+	})
+	mc.SetEvictionPolicy(EvictLFU)
+	mc.SetMaxEntries(2)
+
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(2)
+	assert.Equal(t, 2, timesFetched)
+
+	_, _ = mc.Get(3)
+	assert.Equal(t, 3, timesFetched)
+	_, _ = mc.Get(1)
+	assert.Equal(t, 3, timesFetched, "Key 1 is frequently used, so it should not have been evicted")
+	_, _ = mc.Get(2)
+	assert.Equal(t, 4, timesFetched, "Key 2 should have been evicted")
+
+	assert.Panics(t, func() {
+		mc.SetEvictionPolicy(EvictionPolicy(-1))
+	})
+}
+
+func TestMultiCache_SetMaxCost(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched++
+		return strings.Repeat("a", len(key)), nil
+	})
+	mc.SetMaxCost(10, func(_ string, val string) int64 {
+		return int64(len(val))
+	})
+
+	_, _ = mc.Get("abcd")
+	_, _ = mc.Get("efgh")
+	assert.Equal(t, int64(8), mc.totalCost)
+	assert.Equal(t, 2, timesFetched)
+
+	_, _ = mc.Get("ijklmn")
+	assert.Equal(t, 3, timesFetched)
+	assert.Equal(t, int64(10), mc.totalCost, "abcd should have been evicted to make room")
+	assert.Len(t, mc.values, 2)
+
+	_, _ = mc.Get("efgh")
+	assert.Equal(t, 3, timesFetched, "efgh should still be cached")
+	_, _ = mc.Get("abcd")
+	assert.Equal(t, 4, timesFetched, "abcd should have been reloaded")
+	assert.Equal(t, int64(8), mc.totalCost, "ijklmn should have been evicted as the least recently used")
+
+	mc.Invalidate("abcd")
+	assert.Equal(t, int64(4), mc.totalCost, "Invalidated values should not count toward cost")
+
+	_, _ = mc.Get("a very long key")
+	assert.Len(t, mc.values, 1, "An oversized value should evict all others")
+	_, _ = mc.Get("a very long key")
+	assert.Equal(t, 5, timesFetched, "An oversized value should still be cached")
+}
+
+func TestMultiCache_SetMaxCost_Existing(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3, 4}))
+	mc.SetMaxCost(5, func(key int, val int) int64 {
+		return int64(val)
+	})
+	assert.LessOrEqual(t, mc.totalCost, int64(5), "Existing values should have been weighed and evicted")
+	assert.Panics(t, func() {
+		mc.SetMaxCost(0, func(key int, val int) int64 {
+			return 0
+		})
+	})
+	assert.Panics(t, func() {
+		mc.SetMaxCost(5, nil)
+	})
+}
+
+func TestMultiCache_RemoveExpired(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	_, ok := mc.NextExpiration()
+	assert.False(t, ok, "Nothing should expire yet")
+
+	mc.SetTTLPolicy(ttl)
+	start := time.Now()
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3}))
+	next, ok := mc.NextExpiration()
+	assert.True(t, ok)
+	assert.WithinDuration(t, start.Add(ttl), next, ttl)
+	assert.Equal(t, 0, mc.RemoveExpired(), "Nothing should have expired yet")
+
+	time.Sleep(2 * ttl)
+	_, _ = mc.Get(3)
+	assert.Equal(t, 2, mc.RemoveExpired(), "Expired values should have been removed, except for the reloaded key")
+	assert.Len(t, mc.values, 1)
+	next, ok = mc.NextExpiration()
+	assert.True(t, ok)
+	assert.True(t, next.After(time.Now()), "Key 3 should expire in the future")
+
+	mc.Invalidate(3)
+	_, ok = mc.NextExpiration()
+	assert.False(t, ok, "No values should be left to expire")
+}
+
+func TestMultiCache_GetMulti(t *testing.T) {
+	var (
+		timesFetched atomic.Int32
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		timesFetched.Add(1)
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key * 2, nil
+	})
+	assert.NoError(t, mc.Preheat([]int{1, 2}))
+	assert.Equal(t, int32(2), timesFetched.Load())
+
+	vals, err := mc.GetMulti([]int{1, 2, 3, 4, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{1: 2, 2: 4, 3: 6, 4: 8}, vals)
+	assert.Equal(t, int32(4), timesFetched.Load(), "Only misses should have been loaded, and only once per key")
+
+	vals, err = mc.GetMulti([]int{1, -1, 5})
+	assert.Error(t, err)
+	assert.Equal(t, map[int]int{1: 2, 5: 10}, vals, "Successful values should be returned with an error")
+}
+
+func TestNewMultiBatch(t *testing.T) {
+	var (
+		batches [][]int
+	)
+
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		batches = append(batches, keys)
+		vals := map[int]int{}
+		for _, key := range keys {
+			if key < 0 {
+				continue
+			}
+			vals[key] = key * 2
+		}
+		return vals, nil
+	})
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3}))
+	assert.Len(t, batches, 1, "Preheat should have used a single batch")
+	assert.ElementsMatch(t, []int{1, 2, 3}, batches[0])
+
+	vals, err := mc.GetMulti([]int{2, 3, 4, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{2: 4, 3: 6, 4: 8, 5: 10}, vals)
+	assert.Len(t, batches, 2)
+	assert.ElementsMatch(t, []int{4, 5}, batches[1], "Only misses should have been requested")
+
+	assert.Equal(t, 12, mc.MustGet(6))
+	assert.Equal(t, []int{6}, batches[2], "Get should use the batch loader with a single key")
+
+	vals, err = mc.GetMulti([]int{7, -1})
+	assert.Error(t, err, "A key missing from the batch result should be an error")
+	assert.Equal(t, map[int]int{7: 14}, vals)
+	_, err = mc.Get(-1)
+	assert.Error(t, err)
+	assert.Len(t, batches, 5)
+	assert.Equal(t, 14, mc.MustGet(7))
+	assert.Len(t, batches, 5, "7 should have been cached by the batch")
+}
+
+func TestMultiCache_ForEach(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key * 2, nil
+	})
+	assert.Equal(t, 0, mc.Len())
+	assert.Empty(t, mc.Keys())
+
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3}))
+	_, err := mc.Get(-1)
+	assert.Error(t, err)
+	assert.Equal(t, 3, mc.Len(), "Failed loads should not be counted")
+	assert.ElementsMatch(t, []int{1, 2, 3}, mc.Keys())
+
+	seen := map[int]int{}
+	mc.ForEach(func(key int, val int) bool {
+		seen[key] = val
+		return true
+	})
+	assert.Equal(t, map[int]int{1: 2, 2: 4, 3: 6}, seen)
+
+	var iterations int
+	mc.ForEach(func(_ int, _ int) bool {
+		iterations++
+		return false
+	})
+	assert.Equal(t, 1, iterations, "Iteration should stop when fn returns false")
+
+	mc.Invalidate(2)
+	assert.ElementsMatch(t, []int{1, 3}, mc.Keys())
+
+	mc.SetTTLPolicy(ttl)
+	_, _ = mc.Get(4)
+	time.Sleep(2 * ttl)
+	assert.ElementsMatch(t, []int{1, 3}, mc.Keys(), "Expired values should be skipped")
+}
+
+func TestMultiCache_InvalidateWhere(t *testing.T) {
+	var (
+		timesInvalidated int
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key * 2, nil
+	})
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3, 4, 5}))
+	mc.OnInvalidate(2, func() {
+		timesInvalidated++
+	})
+
+	invalidated := mc.InvalidateWhere(func(key int, val int) bool {
+		return key%2 == 0
+	})
+	assert.Equal(t, 2, invalidated)
+	assert.Equal(t, 1, timesInvalidated, "OnInvalidate should be called for matching values")
+	assert.ElementsMatch(t, []int{1, 3, 5}, mc.Keys())
+
+	invalidated = mc.InvalidateWhere(func(key int, val int) bool {
+		return val > 100
+	})
+	assert.Equal(t, 0, invalidated)
+	assert.Equal(t, 3, mc.Len())
+}
+
+func TestInvalidatePrefix(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched++
+		return strings.ToUpper(key), nil
+	})
+	assert.NoError(t, mc.Preheat([]string{"user:42:name", "user:42:email", "user:7:name", "org:42:name"}))
+	assert.Equal(t, 4, timesFetched)
+
+	assert.Equal(t, 2, InvalidatePrefix(mc, "user:42:"))
+	assert.ElementsMatch(t, []string{"user:7:name", "org:42:name"}, mc.Keys())
+	assert.Equal(t, 0, InvalidatePrefix(mc, "user:42:"))
+
+	_, _ = mc.Get("user:42:name")
+	assert.Equal(t, 5, timesFetched, "Invalidated keys should be reloaded")
+}
+
+func TestMultiCache_Set(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched++
+		return "loaded", nil
+	})
+	mc.SetTTLPolicy(ttl)
+
+	mc.Set("a", "set")
+	assert.Equal(t, "set", mc.MustGet("a"))
+	assert.Equal(t, 0, timesFetched, "The loader should not be called for a set value")
+
+	assert.Equal(t, "loaded", mc.MustGet("b"))
+	assert.Equal(t, 1, timesFetched)
+	mc.Set("b", "replaced")
+	assert.Equal(t, "replaced", mc.MustGet("b"))
+
+	time.Sleep(ttl / 2)
+	mc.Set("a", "set again")
+	time.Sleep(ttl / 2)
+	assert.Equal(t, "set again", mc.MustGet("a"), "Setting a value should reset its time to live")
+	time.Sleep(ttl)
+	assert.Equal(t, "loaded", mc.MustGet("a"), "Set values should still expire")
+	assert.Equal(t, 2, timesFetched)
+}
+
+func TestMultiCache_GetOrSet(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched++
+		return "loaded", nil
+	})
+	actual, loaded := mc.GetOrSet("a", "first")
+	assert.False(t, loaded)
+	assert.Equal(t, "first", actual)
+
+	actual, loaded = mc.GetOrSet("a", "second")
+	assert.True(t, loaded, "The first value should have been adopted")
+	assert.Equal(t, "first", actual)
+	assert.Equal(t, "first", mc.MustGet("a"))
+
+	_, _ = mc.Get("b")
+	actual, loaded = mc.GetOrSet("b", "set")
+	assert.True(t, loaded)
+	assert.Equal(t, "loaded", actual)
+	assert.Equal(t, 1, timesFetched, "GetOrSet should never call the loader")
+
+	const numSetters = 10
+	var (
+		wg      sync.WaitGroup
+		winners atomic.Int32
+	)
+	wg.Add(numSetters)
+	for i := 0; i < numSetters; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			if _, loaded := mc.GetOrSet("c", fmt.Sprintf("%d", i)); !loaded {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), winners.Load(), "Only one concurrent caller should have set a value")
+}
+
+func TestMultiCache_GetIfPresent(t *testing.T) {
+	var (
+		timesFetched int
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched++
+		if key == "bad" {
+			return "", errors.New("bad key")
+		}
+		return strings.ToUpper(key), nil
+	})
+	val, ok := mc.GetIfPresent("a")
+	assert.False(t, ok)
+	assert.Equal(t, "", val)
+	assert.Equal(t, 0, timesFetched, "GetIfPresent should never call the loader")
+
+	_, _ = mc.Get("a")
+	val, ok = mc.GetIfPresent("a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", val)
+
+	_, _ = mc.Get("bad")
+	_, ok = mc.GetIfPresent("bad")
+	assert.False(t, ok, "A failed load should not be present")
+	assert.Equal(t, 2, timesFetched)
+
+	mc.Invalidate("a")
+	_, ok = mc.GetIfPresent("a")
+	assert.False(t, ok)
+}
+
+func TestMultiCache_SetKeyTTL(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+	var (
+		timesFetched = map[string]int{}
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		timesFetched[key]++
+		return key, nil
+	})
+	mc.SetTTLPolicy(time.Minute)
+	mc.SetKeyTTL("admin", ttl)
+	assert.NoError(t, mc.Preheat([]string{"admin", "anonymous"}))
+	time.Sleep(2 * ttl)
+
+	_, _ = mc.Get("admin")
+	_, _ = mc.Get("anonymous")
+	assert.Equal(t, 2, timesFetched["admin"], "The key TTL should have overridden the policy")
+	assert.Equal(t, 1, timesFetched["anonymous"])
+
+	mc.SetKeyTTL("anonymous", ttl)
+	time.Sleep(2 * ttl)
+	_, _ = mc.Get("anonymous")
+	assert.Equal(t, 2, timesFetched["anonymous"], "The key TTL should apply to already cached values")
+
+	mc.RemoveKeyTTL("admin")
+	_, _ = mc.Get("admin")
+	time.Sleep(2 * ttl)
+	_, _ = mc.Get("admin")
+	assert.Equal(t, 2, timesFetched["admin"], "The policy should be used again after the override is removed")
+
+	assert.Panics(t, func() {
+		mc.SetKeyTTL("admin", 0)
+	})
+}
+
+func TestNewMultiWithTTL(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+	var (
+		timesFetched = map[string]int{}
+	)
+
+	mc := NewMultiWithTTL[string, string](func(key string) (string, time.Duration, error) {
+		timesFetched[key]++
+		switch key {
+		case "short":
+			return key, ttl, nil
+		case "invalid":
+			return key, 0, nil
+		default:
+			return key, time.Minute, nil
+		}
+	})
+	mc.SetTTLPolicy(time.Hour)
+	assert.NoError(t, mc.Preheat([]string{"short", "long"}))
+	time.Sleep(2 * ttl)
+
+	_, _ = mc.Get("short")
+	_, _ = mc.Get("long")
+	assert.Equal(t, 2, timesFetched["short"], "The loader's TTL should have taken precedence over the policy")
+	assert.Equal(t, 1, timesFetched["long"])
+
+	_, err := mc.Get("invalid")
+	assert.Error(t, err, "A TTL <= 0 should result in an error")
+}
+
+func TestNewMultiCtx(t *testing.T) {
+	type ctxKey struct{}
+
+	mc := NewMultiCtx[string, string](func(ctx context.Context, key string) (string, error) {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if val, ok := ctx.Value(ctxKey{}).(string); ok {
+			return val, nil
+		}
+		return key, nil
+	})
+	ctx := context.WithValue(context.Background(), ctxKey{}, "from context")
+	val, err := mc.GetCtx(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "from context", val, "The caller's context should be passed to the loader")
+	assert.Equal(t, "b", mc.MustGet("b"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = mc.GetCtx(ctx, "c")
+	assert.ErrorIs(t, err, context.Canceled)
+	val, err = mc.GetCtx(ctx, "a")
+	assert.NoError(t, err, "Cached values should not need the context")
+	assert.Equal(t, "from context", val)
+}
+
+func TestMultiCache_GetCtx_SharedCancel(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+	)
+	mc := NewMultiCtx[string, string](func(ctx context.Context, key string) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		}
+		return key, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := mc.GetCtx(ctx, "a")
+		cancelled <- err
+	}()
+	<-started
+	live := make(chan error, 1)
+	var val string
+	go func() {
+		var err error
+		val, err = mc.GetCtx(context.Background(), "a")
+		live <- err
+	}()
+	// Give the live caller time to start waiting on the cancelled load.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	err := <-live
+	assert.NoError(t, err, "Another caller's cancellation should not be shared")
+	assert.NotErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "a", val)
+	assert.Equal(t, int32(2), calls.Load(), "The live caller should load the value itself")
+}
+
+func TestMultiCache_OnAnyInvalidate(t *testing.T) {
+	var (
+		invalidated []string
+	)
+
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		return key, nil
+	})
+	mc.OnAnyInvalidate(func(key string) {
+		invalidated = append(invalidated, key)
+	})
+	mc.Invalidate("missing")
+	assert.Empty(t, invalidated, "Keys without values should not trigger the handler")
+
+	assert.NoError(t, mc.Preheat([]string{"a", "b", "user:1", "user:2"}))
+	mc.Invalidate("a")
+	assert.Equal(t, []string{"a"}, invalidated)
+
+	InvalidatePrefix(mc, "user:")
+	assert.ElementsMatch(t, []string{"a", "user:1", "user:2"}, invalidated)
+
+	_, _ = mc.Get("c")
+	mc.InvalidateWhere(func(key string, _ string) bool {
+		return key == "c"
+	})
+	assert.ElementsMatch(t, []string{"a", "user:1", "user:2", "c"}, invalidated, "Keys added after registration should trigger the handler")
+}
+
+func TestMultiCache_OnEvict(t *testing.T) {
+	const (
+		ttl = 50 * time.Millisecond
+	)
+	type eviction struct {
+		key    int
+		val    string
+		reason Reason
+	}
+	var (
+		evictions []eviction
+		loads     int
+	)
+
+	mc := NewMulti[int, string](func(key int) (string, error) {
+		loads++
+		return fmt.Sprintf("%d-%d", key, loads), nil
+	})
+	mc.OnEvict(func(key int, val string, reason Reason) {
+		evictions = append(evictions, eviction{key: key, val: val, reason: reason})
+	})
+	mc.SetMaxEntries(2)
+
+	_, _ = mc.Get(1)
+	mc.Invalidate(1)
+	assert.Equal(t, []eviction{{1, "1-1", ReasonInvalidated}}, evictions)
+
+	_, _ = mc.Get(2)
+	_, _ = mc.Get(3)
+	_, _ = mc.Get(4)
+	assert.Equal(t, eviction{2, "2-2", ReasonCapacity}, evictions[1])
+
+	mc.Set(3, "set")
+	assert.Equal(t, eviction{3, "3-3", ReasonReplaced}, evictions[2])
+
+	mc.SetKeyTTL(4, ttl)
+	time.Sleep(2 * ttl)
+	assert.Equal(t, 1, mc.RemoveExpired())
+	assert.Equal(t, eviction{4, "4-4", ReasonExpired}, evictions[3])
+	assert.Len(t, evictions, 4)
+	assert.Equal(t, "expired", ReasonExpired.String())
+}
+
+func TestMultiCache_OnHit(t *testing.T) {
+	var (
+		hits   = map[int]int{}
+		misses = map[int]int{}
+		mux    sync.Mutex
+	)
+
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.OnHit(func(key int) {
+		mux.Lock()
+		defer mux.Unlock()
+		hits[key]++
+	})
+	mc.OnMiss(func(key int) {
+		mux.Lock()
+		defer mux.Unlock()
+		misses[key]++
+	})
+
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	assert.Equal(t, 1, misses[1])
+	assert.Equal(t, 2, hits[1])
+
+	_, ok := mc.GetIfPresent(2)
+	assert.False(t, ok)
+	assert.Equal(t, 1, misses[2])
+
+	_, err := mc.GetMulti([]int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, hits[1])
+	assert.Equal(t, 2, misses[2])
+	_, _ = mc.GetIfPresent(2)
+	assert.Equal(t, 1, hits[2])
+}
+
+func TestMultiCache_Get_SlowLoad(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		started = make(chan struct{})
+	)
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		if key == "slow" {
+			close(started)
+			<-release
+		}
+		return key, nil
+	})
+	assert.NoError(t, mc.Preheat([]string{"a"}))
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		val, err := mc.Get("slow")
+		assert.NoError(t, err)
+		assert.Equal(t, "slow", val)
+	}()
+	<-started
+
+	// Neither hits nor loads of other keys should wait for the slow load.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Equal(t, "a", mc.MustGet("a"))
+		assert.Equal(t, "b", mc.MustGet("b"))
+		mc.Invalidate("a")
+		assert.Equal(t, 1, mc.Len())
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Get of another key was blocked by a slow load")
+	}
+
+	close(release)
+	<-slowDone
+	assert.Equal(t, 2, mc.Len())
+}
+
+func TestMultiCache_OnEvict_Reentrant(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.SetMaxEntries(1)
+	var evicted []int
+	mc.OnEvict(func(key int, val int, _ Reason) {
+		// Handlers are called without the MultiCache's lock, so they may use the MultiCache.
+		_, ok := mc.GetIfPresent(key)
+		assert.False(t, ok)
+		evicted = append(evicted, key)
+	})
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(2)
+	assert.Equal(t, []int{1}, evicted)
+}
+
+func TestMultiCache_SetNegativeTTL(t *testing.T) {
+	var (
+		errNotFound = errors.New("not found")
+		loads       = map[int]int{}
+	)
+	mc := NewMulti[int, string](func(key int) (string, error) {
+		loads[key]++
+		switch {
+		case key < 0:
+			return "", errNotFound
+		case key == 0:
+			return "", errors.New("transient")
+		}
+		return fmt.Sprintf("%d", key), nil
+	})
+	mc.SetNegativeTTL(50*time.Millisecond, func(err error) bool {
+		return errors.Is(err, errNotFound)
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := mc.Get(-1)
+		assert.ErrorIs(t, err, errNotFound)
+		_, err = mc.Get(0)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 1, loads[-1], "Absence should be cached")
+	assert.Equal(t, 3, loads[0], "Other errors should not be cached")
+	stats := mc.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err := mc.Get(-1)
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, 2, loads[-1], "Absence should expire")
+
+	mc.Set(-1, "now exists")
+	assert.Equal(t, "now exists", mc.MustGet(-1))
+
+	assert.Panics(t, func() {
+		mc.SetNegativeTTL(time.Second, nil)
+	})
+}
+
+func TestMultiCache_Get_SharedLoad(t *testing.T) {
+	var (
+		loads   atomic.Int64
+		release = make(chan struct{})
+	)
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		loads.Add(1)
+		<-release
+		return key, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "a", mc.MustGet("a"))
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(1), loads.Load())
+	stats := mc.Stats()
+	assert.Equal(t, uint64(1), stats.Loads)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(9), stats.Hits)
+}
+
+func TestMultiCache_PreheatEach(t *testing.T) {
+	errBadKey := errors.New("bad key")
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		if strings.HasPrefix(key, "bad") {
+			return "", errBadKey
+		}
+		return strings.ToUpper(key), nil
+	})
+
+	err := mc.PreheatEach([]string{"a", "bad1", "b", "bad2", "c"})
+	assert.ErrorIs(t, err, errBadKey)
+	assert.Contains(t, err.Error(), "bad1")
+	assert.Contains(t, err.Error(), "bad2")
+	assert.Equal(t, 3, mc.Len(), "Keys after a failure should still be loaded")
+
+	assert.NoError(t, mc.PreheatEach([]string{"a", "d"}))
+	assert.Equal(t, 4, mc.Len())
+}
+
+func TestMultiCache_PreheatFrom(t *testing.T) {
+	var loads int
+	mc := NewMulti[int, string](func(key int) (string, error) {
+		loads++
+		return fmt.Sprintf("loaded-%d", key), nil
+	})
+	mc.PreheatFrom(map[int]string{
+		1: "one",
+		2: "two",
+	})
+	assert.Equal(t, 2, mc.Len())
+	assert.Equal(t, "one", mc.MustGet(1))
+	assert.Equal(t, "two", mc.MustGet(2))
+	assert.Equal(t, 0, loads)
+
+	assert.Equal(t, "loaded-3", mc.MustGet(3))
+	assert.Equal(t, 1, loads)
+}
+
+func TestMultiCache_PreheatAll(t *testing.T) {
+	flags := map[string]bool{
+		"dark-mode": true,
+		"beta":      false,
+		"search":    true,
+	}
+	mc := NewMulti[string, bool](func(key string) (bool, error) {
+		val, ok := flags[key]
+		if !ok {
+			return false, errors.New("unknown flag")
+		}
+		return val, nil
+	})
+	assert.Error(t, mc.PreheatAll(context.Background()), "A key lister must be set")
+
+	mc.SetKeyLister(func() ([]string, error) {
+		keys := make([]string, 0, len(flags))
+		for key := range flags {
+			keys = append(keys, key)
+		}
+		return keys, nil
+	})
+	assert.NoError(t, mc.PreheatAll(context.Background()))
+	assert.Equal(t, 3, mc.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mc.SetKeyLister(func() ([]string, error) {
+		return []string{"other"}, nil
+	})
+	assert.ErrorIs(t, mc.PreheatAll(ctx), context.Canceled)
+	assert.Equal(t, 3, mc.Len())
+}
+
+func TestMultiCache_SetTTLPolicyJitter(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.SetTTLPolicyJitter(time.Hour, 0.1)
+	keys := make([]int, 50)
+	for i := range keys {
+		keys[i] = i
+	}
+	assert.NoError(t, mc.Preheat(keys))
+
+	expirations := map[time.Time]bool{}
+	for _, key := range keys {
+		at, ok := mc.values[key].value.expiresAt()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), at, 6*time.Minute+time.Second)
+		expirations[at.Truncate(time.Second)] = true
+	}
+	assert.Greater(t, len(expirations), 1, "Expirations should be spread out")
+
+	assert.Panics(t, func() {
+		mc.SetTTLPolicyJitter(time.Hour, 1)
+	})
+}
+
+func TestMultiCache_SetCloner(t *testing.T) {
+	mc := NewMulti[string, []string](func(key string) ([]string, error) {
+		return []string{key}, nil
+	})
+	mc.SetCloner(func(val []string) []string {
+		return append([]string(nil), val...)
+	})
+
+	first := mc.MustGet("a")
+	first[0] = "modified"
+	assert.Equal(t, []string{"a"}, mc.MustGet("a"), "Modifying a returned value should not affect the cache")
+
+	val, ok := mc.GetIfPresent("a")
+	assert.True(t, ok)
+	val[0] = "modified"
+	vals, err := mc.GetMulti([]string{"a"})
+	assert.NoError(t, err)
+	vals["a"][0] = "modified"
+	mc.ForEach(func(_ string, val []string) bool {
+		val[0] = "modified"
+		return true
+	})
+	assert.Equal(t, []string{"a"}, mc.MustGet("a"))
+}
+
+func TestMultiCache_Remove(t *testing.T) {
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		return "loaded-" + key, nil
+	})
+	var (
+		invalidated bool
+		reasons     []Reason
+	)
+	mc.OnAnyInvalidate(func(key string) {
+		invalidated = true
+	})
+	mc.OnEvict(func(_ string, _ string, reason Reason) {
+		reasons = append(reasons, reason)
+	})
+
+	mc.Set("a", "dirty")
+	val, ok := mc.Remove("a")
+	assert.True(t, ok)
+	assert.Equal(t, "dirty", val)
+	assert.Equal(t, 0, mc.Len())
+	assert.False(t, invalidated)
+	assert.Equal(t, []Reason{ReasonRemoved}, reasons)
+
+	_, ok = mc.Remove("a")
+	assert.False(t, ok)
+	assert.Equal(t, "loaded-a", mc.MustGet("a"))
+}
+
+func TestNewMultiSized(t *testing.T) {
+	mc := NewMultiSized[int, int](func(key int) (int, error) {
+		return key * 2, nil
+	}, 1000)
+	mc.SetTTLPolicy(time.Minute)
+	for i := 0; i < 2000; i++ {
+		assert.Equal(t, i*2, mc.MustGet(i))
+	}
+	assert.Equal(t, 2000, mc.Len())
+
+	assert.Panics(t, func() {
+		NewMultiSized[int, int](func(key int) (int, error) {
+			return key, nil
+		}, -1)
+	})
+	assert.Panics(t, func() {
+		NewMultiSized[int, int](nil, 10)
+	})
+}
+
+func TestMultiCache_ExpiresAt(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	_, ok := mc.ExpiresAt(1)
+	assert.False(t, ok)
+	_, ok = mc.TTLRemaining(1)
+	assert.False(t, ok)
+
+	mc.MustGet(1)
+	at, ok := mc.ExpiresAt(1)
+	assert.True(t, ok)
+	assert.True(t, at.IsZero())
+	remaining, ok := mc.TTLRemaining(1)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), remaining)
+
+	mc.SetTTLPolicy(time.Hour)
+	mc.MustGet(2)
+	at, ok = mc.ExpiresAt(2)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), at, time.Second)
+	remaining, ok = mc.TTLRemaining(2)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, remaining, float64(time.Second))
+
+	mc.Invalidate(2)
+	_, ok = mc.TTLRemaining(2)
+	assert.False(t, ok)
+}
@@ -0,0 +1,27 @@
+package cache
+
+// KeyNormalizer returns the canonical form of a key, such as by converting it to lower case or trimming whitespace.
+// Keys that normalize to the same key are treated as the same key by a [MultiCache].
+type KeyNormalizer[K comparable] func(key K) K
+
+// SetKeyNormalizer sets a [KeyNormalizer] that will be applied to every key passed to the MultiCache before it's used.
+// This prevents logically identical keys, such as usernames that only differ by case, from being cached and loaded separately.
+// The loader, handlers, and methods that report keys, such as Keys and ForEach, will only see normalized keys.
+// GetMulti returns values keyed by the keys it was given, so callers can find each value with the key they requested.
+//
+// The normalizer must be idempotent, since a key may be normalized more than once, and should be set before the MultiCache is used.
+// Keys that are already cached are not normalized again, so changing the normalizer may leave values that can't be found until they expire or are evicted.
+// Passing nil will remove the normalizer.
+func (m *MultiCache[K, V]) SetKeyNormalizer(normalizer KeyNormalizer[K]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.normalizer = normalizer
+	})
+}
+
+// normalize returns the canonical form of key, according to the KeyNormalizer set with SetKeyNormalizer.
+func (m *MultiCache[K, V]) normalize(key K) K {
+	if normalizer := m.hooks.Load().normalizer; normalizer != nil {
+		return normalizer(key)
+	}
+	return key
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetKeyNormalizer(t *testing.T) {
+	var calls atomic.Int32
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		calls.Add(1)
+		return "user:" + key, nil
+	})
+	mc.SetKeyNormalizer(func(key string) string {
+		return strings.ToLower(strings.TrimSpace(key))
+	})
+
+	val, err := mc.Get("Bob")
+	require.NoError(t, err)
+	assert.Equal(t, "user:bob", val)
+	assert.Equal(t, "user:bob", mc.MustGet(" BOB "))
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, []string{"bob"}, mc.Keys())
+
+	vals, err := mc.GetMulti([]string{"BOB", "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"BOB": "user:bob", "Alice": "user:alice"}, vals)
+	assert.Equal(t, int32(2), calls.Load())
+
+	mc.Set("CAROL", "set")
+	val, ok := mc.GetIfPresent("carol")
+	assert.True(t, ok)
+	assert.Equal(t, "set", val)
+
+	mc.Invalidate("ALICE")
+	_, ok = mc.GetIfPresent("alice")
+	assert.False(t, ok)
+
+	mc.Pin("Bob")
+	assert.True(t, mc.IsPinned("bob"))
+}
+
+func TestShardedMultiCache_SetKeyNormalizer(t *testing.T) {
+	var calls atomic.Int32
+	s := NewShardedMulti[string, string](8, func(key string) (string, error) {
+		calls.Add(1)
+		return key, nil
+	})
+	s.SetKeyNormalizer(strings.ToLower)
+
+	for _, key := range []string{"Bob", "BOB", "bob", "bOb"} {
+		assert.Equal(t, "bob", s.MustGet(key))
+	}
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 1, s.Len())
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"sort"
+	"time"
+)
+
+// orderedEntry is a snapshot of a valid value, along with the times used to order it.
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	val        V
+	lastAccess int64
+	expiresAt  time.Time
+	expires    bool
+}
+
+// ForEachByRecency is the same as ForEach, except that values are visited in order of their last access, starting with the least recently used.
+// This is useful for tooling that shows which values haven't been used in a while.
+// A value is considered accessed when it's loaded, set, or read with Get, GetIfPresent, or GetMulti.
+//
+// Every valid value is copied and sorted before fn is first called, so this should not be called on every request.
+func (m *MultiCache[K, V]) ForEachByRecency(fn func(key K, val V) bool) {
+	visitOrdered(m.snapshotOrdered(), byRecency[K, V], fn)
+}
+
+// ForEachByExpiration is the same as ForEach, except that values are visited in order of their expiration, starting with the value that will expire soonest.
+// Values without a time to live are visited last.
+// This is useful for tooling that shows which values are about to expire.
+//
+// Every valid value is copied and sorted before fn is first called, so this should not be called on every request.
+func (m *MultiCache[K, V]) ForEachByExpiration(fn func(key K, val V) bool) {
+	visitOrdered(m.snapshotOrdered(), byExpiration[K, V], fn)
+}
+
+// snapshotOrdered returns an orderedEntry for each valid value in the MultiCache.
+// Like ForEach, values that are currently being loaded are skipped.
+func (m *MultiCache[K, V]) snapshotOrdered() []orderedEntry[K, V] {
+	var snapshot []orderedEntry[K, V]
+	for _, ke := range m.entries() {
+		val, ok := ke.entry.value.tryCached()
+		if !ok {
+			continue
+		}
+		oe := orderedEntry[K, V]{
+			key:        ke.key,
+			val:        m.clone(val),
+			lastAccess: ke.entry.lastAccess.Load(),
+		}
+		oe.expiresAt, oe.expires, _ = ke.entry.value.tryExpiresAt()
+		snapshot = append(snapshot, oe)
+	}
+	return snapshot
+}
+
+// byRecency orders a before b if it was accessed less recently.
+func byRecency[K comparable, V any](a, b orderedEntry[K, V]) bool {
+	return a.lastAccess < b.lastAccess
+}
+
+// byExpiration orders a before b if it will expire sooner, where values that don't expire are ordered last.
+func byExpiration[K comparable, V any](a, b orderedEntry[K, V]) bool {
+	if a.expires != b.expires {
+		return a.expires
+	}
+	return a.expiresAt.Before(b.expiresAt)
+}
+
+// visitOrdered sorts snapshot with less, and calls fn with each value until it returns false.
+func visitOrdered[K comparable, V any](snapshot []orderedEntry[K, V], less func(a, b orderedEntry[K, V]) bool, fn func(key K, val V) bool) {
+	sort.SliceStable(snapshot, func(i, j int) bool {
+		return less(snapshot[i], snapshot[j])
+	})
+	for _, oe := range snapshot {
+		if !fn(oe.key, oe.val) {
+			return
+		}
+	}
+}
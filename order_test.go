@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_ForEachByRecency(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	for _, key := range []int{1, 2, 3} {
+		mc.MustGet(key)
+		time.Sleep(time.Millisecond)
+	}
+	mc.MustGet(1)
+
+	var keys []int
+	mc.ForEachByRecency(func(key int, val int) bool {
+		assert.Equal(t, key, val)
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{2, 3, 1}, keys)
+
+	keys = nil
+	mc.ForEachByRecency(func(key int, _ int) bool {
+		keys = append(keys, key)
+		return false
+	})
+	assert.Equal(t, []int{2}, keys)
+}
+
+func TestMultiCache_ForEachByExpiration(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.SetTTLPolicy(time.Hour)
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3, 4}))
+	mc.SetKeyTTL(3, time.Minute)
+	mc.SetKeyTTL(2, 30*time.Minute)
+	mc.Pin(1)
+
+	var keys []int
+	mc.ForEachByExpiration(func(key int, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{3, 2, 4, 1}, keys)
+}
+
+func TestShardedMultiCache_ForEachByRecency(t *testing.T) {
+	s := NewShardedMulti[int, int](4, func(key int) (int, error) {
+		return key, nil
+	})
+	for _, key := range []int{5, 3, 8, 1} {
+		s.MustGet(key)
+		time.Sleep(time.Millisecond)
+	}
+
+	var keys []int
+	s.ForEachByRecency(func(key int, _ int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{5, 3, 8, 1}, keys)
+}
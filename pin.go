@@ -0,0 +1,62 @@
+package cache
+
+// Pin exempts the value for key from expiration and eviction until Unpin is called.
+// This is useful for values that must always be available, such as the configuration of a default tenant.
+// A pinned value will not expire, won't be chosen for eviction by SetMaxEntries or SetMaxCost, and won't be removed for being idle.
+// It may still be invalidated or removed explicitly.
+//
+// The key doesn't need to be cached to be pinned, and a pinned key will stay pinned if its value is invalidated and reloaded.
+// Pinned values still count towards the limits set with SetMaxEntries and SetMaxCost, so a MultiCache with many pinned values may exceed them.
+func (m *MultiCache[K, V]) Pin(key K) {
+	key = m.normalize(key)
+	m.lock.Lock()
+	m.pinned[key] = struct{}{}
+	entry, ok := m.values[key]
+	if ok && m.policy != nil {
+		m.policy.remove(key)
+	}
+	m.lock.Unlock()
+	if ok {
+		entry.pinned.Store(true)
+		m.idle.unschedule(key)
+		m.applyTTL(key, entry, 0)
+	}
+}
+
+// Unpin reverses Pin, so the value for key will expire and may be evicted as normal.
+// The value's time to live is reset to the MultiCache's TTL policy, or the time to live set with SetKeyTTL.
+// A MultiCache created with NewMultiWithTTL will use the loader's time to live the next time the value is loaded.
+//
+// If the MultiCache is over capacity, then values will be evicted immediately.
+func (m *MultiCache[K, V]) Unpin(key K) {
+	key = m.normalize(key)
+	m.lock.Lock()
+	if _, ok := m.pinned[key]; !ok {
+		m.lock.Unlock()
+		return
+	}
+	delete(m.pinned, key)
+	ttl := m.ttlFor(key)
+	entry, ok := m.values[key]
+	if ok {
+		entry.pinned.Store(false)
+		if m.policy != nil {
+			m.policy.add(key)
+			m.evictOverCapacity(0)
+		}
+	}
+	m.unlock()
+	if ok {
+		m.touchIdle(key, entry)
+		m.applyTTL(key, entry, ttl)
+	}
+}
+
+// IsPinned returns true if key has been pinned with Pin.
+func (m *MultiCache[K, V]) IsPinned(key K) bool {
+	key = m.normalize(key)
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	_, ok := m.pinned[key]
+	return ok
+}
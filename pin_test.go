@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Pin(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		return key, nil
+	})
+	mc.SetTTLPolicy(10 * time.Millisecond)
+	mc.SetMaxEntries(2)
+
+	mc.Pin(0)
+	assert.True(t, mc.IsPinned(0))
+	assert.False(t, mc.IsPinned(1))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, mc.MustGet(i))
+	}
+	_, ok := mc.GetIfPresent(0)
+	assert.True(t, ok, "pinned value should not be evicted")
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = mc.GetIfPresent(0)
+	assert.True(t, ok, "pinned value should not expire")
+	mc.RemoveExpired()
+	assert.Equal(t, []int{0}, sortedKeys(mc))
+
+	mc.Invalidate(0)
+	_, ok = mc.GetIfPresent(0)
+	assert.False(t, ok, "pinned value may still be invalidated")
+	assert.Equal(t, 0, mc.MustGet(0))
+	assert.True(t, mc.IsPinned(0))
+
+	mc.Unpin(0)
+	assert.False(t, mc.IsPinned(0))
+	time.Sleep(20 * time.Millisecond)
+	_, ok = mc.GetIfPresent(0)
+	assert.False(t, ok, "unpinned value should expire")
+}
+
+func TestMultiCache_Pin_Existing(t *testing.T) {
+	mc := NewMultiWithTTL[int, int](func(key int) (int, time.Duration, error) {
+		return key, 10 * time.Millisecond, nil
+	})
+	mc.SetMaxEntries(1)
+	mc.SetMaxIdle(time.Hour)
+	assert.Equal(t, 1, mc.MustGet(1))
+	mc.Pin(1)
+	assert.Equal(t, 2, mc.MustGet(2))
+	assert.Equal(t, []int{1, 2}, sortedKeys(mc))
+
+	time.Sleep(20 * time.Millisecond)
+	mc.Invalidate(2)
+	assert.Equal(t, []int{1}, sortedKeys(mc), "pinned value should not expire")
+	mc.Invalidate(1)
+	assert.Equal(t, 1, mc.MustGet(1))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []int{1}, sortedKeys(mc), "reloaded pinned value should ignore the loader's time to live")
+
+	assert.Equal(t, 2, mc.MustGet(2))
+	mc.Unpin(1)
+	assert.Equal(t, 1, mc.Len(), "unpinning should evict values over capacity")
+}
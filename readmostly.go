@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Cache[string, string] = (*ReadMostlyMultiCache[string, string])(nil)
+
+// ReadMostlyMultiCache caches multiple values of type V by key K, like [MultiCache], but is backed by a [sync.Map] instead of a map guarded by a lock.
+// This performs better for key sets that are read far more often than they change, such as service discovery or schema metadata, since reads of existing keys never contend on a shared lock.
+// For workloads that frequently add or remove keys, a MultiCache or [ShardedMultiCache] will perform better.
+//
+// A ReadMostlyMultiCache supports a smaller set of features than a MultiCache, and has no capacity limits, so it should only be used with bounded key sets.
+type ReadMostlyMultiCache[K comparable, V any] struct {
+	values sync.Map
+	loader MultiLoaderFunc[K, V]
+	ttl    atomic.Int64
+	stats  statsCounter
+}
+
+// NewReadMostlyMulti will create a new ReadMostlyMultiCache with the given loader.
+// If the loader is nil, then this function will panic.
+func NewReadMostlyMulti[K comparable, V any](loader MultiLoaderFunc[K, V]) *ReadMostlyMultiCache[K, V] {
+	if loader == nil {
+		panic("nil loader")
+	}
+	return &ReadMostlyMultiCache[K, V]{
+		loader: loader,
+	}
+}
+
+// value returns the Value for key, and creates it if it doesn't exist yet.
+func (r *ReadMostlyMultiCache[K, V]) value(key K) *Value[V] {
+	if v, ok := r.values.Load(key); ok {
+		return v.(*Value[V])
+	}
+	c := New[V](func() (V, error) {
+		return r.loader(key)
+	})
+	if ttl := time.Duration(r.ttl.Load()); ttl > 0 {
+		c.SetTTL(ttl)
+	}
+	v, _ := r.values.LoadOrStore(key, c)
+	return v.(*Value[V])
+}
+
+// SetTTLPolicy sets the time to live of values added to the ReadMostlyMultiCache after this method is called.
+// Like [MultiCache.SetTTLPolicy], this should be called before any values are retrieved.
+//
+// This method will panic if ttl <= 0.
+func (r *ReadMostlyMultiCache[K, V]) SetTTLPolicy(ttl time.Duration) {
+	if ttl <= 0 {
+		panic("ttl <= 0")
+	}
+	r.ttl.Store(int64(ttl))
+}
+
+// Preheat will load the values associated with each key in keys.
+// This will return the first error encountered and stop processing further keys.
+func (r *ReadMostlyMultiCache[K, V]) Preheat(keys []K) error {
+	for _, key := range keys {
+		_, err := r.Get(key)
+		if err != nil {
+			return fmt.Errorf("error preheating cache with key '%v': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Get will return the value associated with key, loading it if needed.
+// Like [MultiCache.Get], concurrent requests for the same missing key will share a single load, and errors are wrapped in a [LoadError].
+func (r *ReadMostlyMultiCache[K, V]) Get(key K) (V, error) {
+	value := r.value(key)
+	val, outcome, err := value.getWith(value.loadFunc)
+	switch {
+	case outcome == outcomeCached || (outcome == outcomeShared && err == nil):
+		r.stats.hits.Add(1)
+	case outcome == outcomeLoaded:
+		r.stats.misses.Add(1)
+		r.stats.recordLoads(1, err)
+	default:
+		r.stats.misses.Add(1)
+	}
+	if err != nil {
+		return val, &LoadError{Key: key, Err: err}
+	}
+	return val, nil
+}
+
+// MustGet does the same thing as Get, but it will panic if an error occurs.
+func (r *ReadMostlyMultiCache[K, V]) MustGet(key K) V {
+	val, err := r.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// GetIfPresent will return the value associated with key and true if a valid value is cached, without loading it.
+func (r *ReadMostlyMultiCache[K, V]) GetIfPresent(key K) (V, bool) {
+	var (
+		val V
+		ok  bool
+	)
+	if v, exists := r.values.Load(key); exists {
+		val, ok = v.(*Value[V]).cached()
+	}
+	if ok {
+		r.stats.hits.Add(1)
+	} else {
+		r.stats.misses.Add(1)
+	}
+	return val, ok
+}
+
+// Set will cache val for key without calling the loader, replacing any existing value.
+func (r *ReadMostlyMultiCache[K, V]) Set(key K, val V) {
+	r.value(key).store(val)
+}
+
+// Invalidate will remove the value associated with key, if it exists.
+func (r *ReadMostlyMultiCache[K, V]) Invalidate(key K) {
+	if v, ok := r.values.LoadAndDelete(key); ok {
+		v.(*Value[V]).Invalidate()
+	}
+}
+
+// Len returns the number of valid values currently held in the ReadMostlyMultiCache.
+func (r *ReadMostlyMultiCache[K, V]) Len() int {
+	var n int
+	r.ForEach(func(_ K, _ V) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// Keys returns the keys of all valid values currently held in the ReadMostlyMultiCache, in no particular order.
+func (r *ReadMostlyMultiCache[K, V]) Keys() []K {
+	var keys []K
+	r.ForEach(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// ForEach calls fn with each valid key and value currently held in the ReadMostlyMultiCache, in no particular order.
+// Iteration will stop if fn returns false.
+// Values that are expired, failed to load, or are currently being loaded are skipped.
+func (r *ReadMostlyMultiCache[K, V]) ForEach(fn func(key K, val V) bool) {
+	r.values.Range(func(key, v any) bool {
+		val, ok := v.(*Value[V]).tryCached()
+		if !ok {
+			return true
+		}
+		return fn(key.(K), val)
+	})
+}
+
+// Stats returns a snapshot of the ReadMostlyMultiCache's aggregate counters.
+// Evictions are not tracked, since values are only removed by invalidation.
+func (r *ReadMostlyMultiCache[K, V]) Stats() Stats {
+	stats := r.stats.snapshot()
+	stats.Entries = r.Len()
+	return stats
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMostlyMultiCache(t *testing.T) {
+	var loads atomic.Int64
+	rc := NewReadMostlyMulti[string, string](func(key string) (string, error) {
+		loads.Add(1)
+		if key == "" {
+			return "", errors.New("empty key")
+		}
+		return "value-" + key, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := fmt.Sprintf("%d", j)
+				assert.Equal(t, "value-"+key, rc.MustGet(key))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(50), loads.Load())
+	assert.Equal(t, 50, rc.Len())
+
+	_, err := rc.Get("")
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, "", loadErr.Key)
+	assert.Equal(t, 50, rc.Len(), "failed loads should not be counted")
+
+	rc.Invalidate("1")
+	_, ok := rc.GetIfPresent("1")
+	assert.False(t, ok)
+	rc.Set("1", "set")
+	val, ok := rc.GetIfPresent("1")
+	assert.True(t, ok)
+	assert.Equal(t, "set", val)
+
+	keys := rc.Keys()
+	sort.Strings(keys)
+	assert.Len(t, keys, 50)
+	assert.Equal(t, "0", keys[0])
+
+	stats := rc.Stats()
+	assert.Equal(t, uint64(51), stats.Loads)
+	assert.Equal(t, uint64(1), stats.LoadFailures)
+	assert.Equal(t, 50, stats.Entries)
+	assert.Equal(t, uint64(400+3), stats.Hits+stats.Misses)
+}
+
+func TestReadMostlyMultiCache_TTL(t *testing.T) {
+	var loads int
+	rc := NewReadMostlyMulti[int, int](func(key int) (int, error) {
+		loads++
+		return loads, nil
+	})
+	rc.SetTTLPolicy(10 * time.Millisecond)
+	require.NoError(t, rc.Preheat([]int{1}))
+	assert.Equal(t, 1, rc.MustGet(1))
+	time.Sleep(20 * time.Millisecond)
+	_, ok := rc.GetIfPresent(1)
+	assert.False(t, ok)
+	assert.Equal(t, 2, rc.MustGet(1))
+
+	assert.Panics(t, func() {
+		rc.SetTTLPolicy(0)
+	})
+	assert.Panics(t, func() {
+		NewReadMostlyMulti[int, int](nil)
+	})
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// ReadOnlyMultiCache is a view of a [MultiCache] that can only read values.
+// It's useful for handing a shared MultiCache to untrusted plugins or lower layers that must not mutate its state.
+// Methods that would change the MultiCache, such as Set and Invalidate, are not available, so attempts to mutate it are rejected at compile time.
+//
+// Note that reading a value may still cause it to be loaded by the MultiCache's loader.
+type ReadOnlyMultiCache[K comparable, V any] struct {
+	m *MultiCache[K, V]
+}
+
+// ReadOnly returns a read-only view of the MultiCache.
+// Values read through the view are the same values held by the MultiCache.
+func (m *MultiCache[K, V]) ReadOnly() *ReadOnlyMultiCache[K, V] {
+	return &ReadOnlyMultiCache[K, V]{m: m}
+}
+
+// Get will return the value associated with key, loading it if needed.
+// See [MultiCache.Get] for details.
+func (r *ReadOnlyMultiCache[K, V]) Get(key K) (V, error) {
+	return r.m.Get(key)
+}
+
+// GetCtx is the same as Get, except that ctx will be passed to a context-aware loader.
+// See [MultiCache.GetCtx] for details.
+func (r *ReadOnlyMultiCache[K, V]) GetCtx(ctx context.Context, key K) (V, error) {
+	return r.m.GetCtx(ctx, key)
+}
+
+// MustGet does the same thing as Get, but it will panic if an error occurs.
+func (r *ReadOnlyMultiCache[K, V]) MustGet(key K) V {
+	return r.m.MustGet(key)
+}
+
+// GetIfPresent will return the value associated with key and true if a valid value is cached, without loading it.
+// See [MultiCache.GetIfPresent] for details.
+func (r *ReadOnlyMultiCache[K, V]) GetIfPresent(key K) (V, bool) {
+	return r.m.GetIfPresent(key)
+}
+
+// ExpiresAt returns the time that the value for key will expire, and true if a valid value is cached.
+// See [MultiCache.ExpiresAt] for details.
+func (r *ReadOnlyMultiCache[K, V]) ExpiresAt(key K) (time.Time, bool) {
+	return r.m.ExpiresAt(key)
+}
+
+// TTLRemaining returns the time remaining until the value for key expires, and true if a valid value is cached.
+// See [MultiCache.TTLRemaining] for details.
+func (r *ReadOnlyMultiCache[K, V]) TTLRemaining(key K) (time.Duration, bool) {
+	return r.m.TTLRemaining(key)
+}
+
+// GetMulti will return the values associated with each key in keys.
+// See [MultiCache.GetMulti] for details.
+func (r *ReadOnlyMultiCache[K, V]) GetMulti(keys []K) (map[K]V, error) {
+	return r.m.GetMulti(keys)
+}
+
+// Len returns the number of valid values currently held in the MultiCache.
+func (r *ReadOnlyMultiCache[K, V]) Len() int {
+	return r.m.Len()
+}
+
+// Keys returns the keys of all valid values currently held in the MultiCache, in no particular order.
+func (r *ReadOnlyMultiCache[K, V]) Keys() []K {
+	return r.m.Keys()
+}
+
+// ForEach calls fn with each valid key and value currently held in the MultiCache, in no particular order.
+// See [MultiCache.ForEach] for details.
+func (r *ReadOnlyMultiCache[K, V]) ForEach(fn func(key K, val V) bool) {
+	r.m.ForEach(fn)
+}
+
+// Snapshot returns a point-in-time copy of every valid key and value.
+// See [MultiCache.Snapshot] for details.
+func (r *ReadOnlyMultiCache[K, V]) Snapshot() map[K]V {
+	return r.m.Snapshot()
+}
+
+// Stats returns a snapshot of the MultiCache's aggregate counters.
+func (r *ReadOnlyMultiCache[K, V]) Stats() Stats {
+	return r.m.Stats()
+}
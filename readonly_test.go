@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_ReadOnly(t *testing.T) {
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		return len(key), nil
+	})
+	ro := mc.ReadOnly()
+
+	assert.Equal(t, 3, ro.MustGet("abc"))
+	val, ok := ro.GetIfPresent("abc")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	assert.Equal(t, []string{"abc"}, ro.Keys())
+
+	// Changes to the MultiCache are visible through the view.
+	mc.Set("abc", 10)
+	assert.Equal(t, 10, ro.MustGet("abc"))
+	mc.Invalidate("abc")
+	assert.Equal(t, 0, ro.Len())
+
+	_, isCache := any(ro).(Cache[string, int])
+	assert.False(t, isCache, "A read-only view should not implement Cache")
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// SetRefreshAhead enables refreshing values in the background before they expire.
+// When a valid value is read within window of its expiration, it will be reloaded in the background while the current value continues to be served.
+// This keeps frequently read keys from ever going cold, while keys that aren't read are still allowed to expire.
+//
+// This has no effect on values without a time to live, so a TTL policy should also be set.
+// This method will panic if window <= 0.
+func (m *MultiCache[K, V]) SetRefreshAhead(window time.Duration) {
+	if window <= 0 {
+		panic("window <= 0")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.refreshAhead = window
+}
+
+// SetStaleWhileRevalidate enables serving expired values while they're reloaded in the background.
+// When a value has expired less than maxStale ago, Get will return it immediately and reload it in the background, rather than waiting for the loader.
+// Once a value has been expired for longer than maxStale, Get will wait for it to be reloaded as usual.
+//
+// This is the biggest latency improvement available for read-heavy services, at the cost of sometimes serving a value that's older than its time to live.
+// RemoveExpired will not remove a value until it has been expired for longer than maxStale.
+// This method will panic if maxStale <= 0.
+func (m *MultiCache[K, V]) SetStaleWhileRevalidate(maxStale time.Duration) {
+	if maxStale <= 0 {
+		panic("max stale <= 0")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.maxStale = maxStale
+}
+
+// SetServeStaleOnError sets whether the previous value for a key should be returned when reloading it fails.
+// This protects availability during upstream incidents, since callers will continue to receive the last known value rather than an error.
+// The value remains expired, so each subsequent Get will attempt to reload it.
+// Keys without a previous value, and errors reported as absent with SetNegativeTTL, will still return an error.
+//
+// The number of times a previous value was served in place of an error is reported by [Stats].
+func (m *MultiCache[K, V]) SetServeStaleOnError(enabled bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.staleOnError = enabled
+}
+
+// SetEarlyRefresh enables probabilistic early refreshing, which prevents many callers from reloading a popular value at the same time when it expires.
+// Each time a valid value is read, it may be reloaded in the background, with a probability that increases as its expiration approaches.
+// Values that took longer to load are refreshed earlier, so they're less likely to be requested after they've expired.
+// This is the XFetch algorithm from "Optimal Probabilistic Cache Stampede Prevention" by Vattani, Chierichetti, and Lowenstein.
+//
+// beta scales how early values are refreshed, and 1 is a good default.
+// Values greater than 1 favor refreshing earlier, and values less than 1 favor refreshing later.
+// Like SetRefreshAhead, this has no effect on values without a time to live, and values cached with Set aren't refreshed until they've been loaded once.
+// This method will panic if beta <= 0.
+func (m *MultiCache[K, V]) SetEarlyRefresh(beta float64) {
+	if beta <= 0 {
+		panic("beta <= 0")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.earlyBeta = beta
+}
+
+// shouldRefreshEarly returns true if the value in entry should be refreshed before it expires, according to SetEarlyRefresh.
+func (m *MultiCache[K, V]) shouldRefreshEarly(entry *multiEntry[K, V], beta float64) bool {
+	if beta <= 0 {
+		return false
+	}
+	delta := entry.loadDuration.Load()
+	if delta <= 0 {
+		return false
+	}
+	at, ok := entry.value.expiresAt()
+	if !ok {
+		return false
+	}
+	// -ln(x) is exponentially distributed for x in (0, 1], so a refresh becomes more likely as the expiration approaches.
+	gap := time.Duration(float64(delta) * beta * -math.Log(1-rand.Float64()))
+	return !time.Now().Add(gap).Before(at)
+}
+
+// shouldRefreshAhead returns true if the value in entry will expire within window.
+func (m *MultiCache[K, V]) shouldRefreshAhead(entry *multiEntry[K, V], window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	at, ok := entry.value.expiresAt()
+	return ok && time.Until(at) < window
+}
+
+// refreshAsync reloads the value for key in the background, unless a refresh is already in progress.
+func (m *MultiCache[K, V]) refreshAsync(key K, entry *multiEntry[K, V]) {
+	if !entry.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+	shedder := m.shedder.Load()
+	if shedder != nil && !shedder.tryAcquire() {
+		entry.refreshing.Store(false)
+		m.stats.loadsShed.Add(1)
+		return
+	}
+	go func() {
+		defer entry.refreshing.Store(false)
+		if shedder != nil {
+			defer shedder.release()
+		}
+		start := time.Now()
+		loader := m.refreshLoader(context.Background(), key)
+		if timeout := time.Duration(m.loadTimeout.Load()); timeout > 0 {
+			loader = timeoutTTLLoader(context.Background(), timeout, func(ctx context.Context) (V, time.Duration, error) {
+				return m.refreshLoader(ctx, key)()
+			})
+		}
+		if limiter := m.limiter.Load(); limiter != nil {
+			loader = limitRefresh(limiter, loader)
+		}
+		err := entry.value.refresh(loader)
+		duration := time.Since(start)
+		m.recordLoad(key, err, duration)
+		if err == nil {
+			entry.loadDuration.Store(int64(duration))
+		}
+		m.afterLoad(key, entry)
+	}()
+}
+
+// refreshLoader returns a LoaderTTLFunc that loads the value for key with the MultiCache's loader.
+// ctx is only passed to a context-aware loader.
+func (m *MultiCache[K, V]) refreshLoader(ctx context.Context, key K) LoaderTTLFunc[V] {
+	switch {
+	case m.ttlLoader != nil:
+		return func() (V, time.Duration, error) {
+			val, ttl, err := m.ttlLoader(key)
+			if err == nil && ttl <= 0 {
+				var mt V
+				return mt, 0, fmt.Errorf("%w: ttl <= 0", ErrMisconfigured)
+			}
+			return val, ttl, err
+		}
+	case m.ctxLoader != nil:
+		return func() (V, time.Duration, error) {
+			val, err := m.ctxLoader(ctx, key)
+			return val, 0, err
+		}
+	default:
+		return func() (V, time.Duration, error) {
+			val, err := m.loadKey(key)
+			return val, 0, err
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_SetRefreshAhead(t *testing.T) {
+	var loads atomic.Int64
+	mc := NewMulti[string, int64](func(key string) (int64, error) {
+		return loads.Add(1), nil
+	})
+	mc.SetTTLPolicy(100 * time.Millisecond)
+	mc.SetRefreshAhead(60 * time.Millisecond)
+
+	assert.Equal(t, int64(1), mc.MustGet("hot"))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(1), mc.MustGet("hot"), "The current value should be served while refreshing")
+	assert.Eventually(t, func() bool {
+		return loads.Load() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// The original value would have expired by now, but the refreshed value is still valid.
+	time.Sleep(70 * time.Millisecond)
+	val, ok := mc.GetIfPresent("hot")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), val)
+	assert.Equal(t, int64(2), loads.Load())
+
+	assert.Panics(t, func() {
+		mc.SetRefreshAhead(0)
+	})
+}
+
+func TestMultiCache_SetRefreshAhead_Idle(t *testing.T) {
+	var loads atomic.Int64
+	mc := NewMulti[string, int64](func(key string) (int64, error) {
+		return loads.Add(1), nil
+	})
+	mc.SetTTLPolicy(50 * time.Millisecond)
+	mc.SetRefreshAhead(40 * time.Millisecond)
+
+	assert.Equal(t, int64(1), mc.MustGet("idle"))
+	time.Sleep(60 * time.Millisecond)
+	_, ok := mc.GetIfPresent("idle")
+	assert.False(t, ok, "Keys that aren't read should still expire")
+	assert.Equal(t, int64(1), loads.Load())
+}
+
+func TestMultiCache_SetStaleWhileRevalidate(t *testing.T) {
+	var (
+		loads   atomic.Int64
+		release = make(chan struct{})
+	)
+	mc := NewMulti[string, int64](func(key string) (int64, error) {
+		n := loads.Add(1)
+		if n > 1 {
+			<-release
+		}
+		return n, nil
+	})
+	mc.SetTTLPolicy(20 * time.Millisecond)
+	mc.SetStaleWhileRevalidate(100 * time.Millisecond)
+
+	assert.Equal(t, int64(1), mc.MustGet("a"))
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 0, mc.RemoveExpired(), "Stale values should be kept for revalidation")
+
+	// The stale value is returned without waiting for the blocked reload.
+	assert.Equal(t, int64(1), mc.MustGet("a"))
+	assert.Equal(t, int64(1), mc.MustGet("a"))
+	close(release)
+	assert.Eventually(t, func() bool {
+		val, ok := mc.GetIfPresent("a")
+		return ok && val == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(2), loads.Load(), "Only one background reload should be started")
+
+	// Values that have been stale for too long are loaded synchronously.
+	time.Sleep(130 * time.Millisecond)
+	assert.Equal(t, int64(3), mc.MustGet("a"))
+}
+
+func TestMultiCache_SetServeStaleOnError(t *testing.T) {
+	var failing atomic.Bool
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		if failing.Load() {
+			return "", errors.New("upstream unavailable")
+		}
+		return "value-" + key, nil
+	})
+	mc.SetTTLPolicy(10 * time.Millisecond)
+	mc.SetServeStaleOnError(true)
+
+	assert.Equal(t, "value-a", mc.MustGet("a"))
+	failing.Store(true)
+	time.Sleep(20 * time.Millisecond)
+
+	val, err := mc.Get("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "value-a", val)
+	_, err = mc.Get("b")
+	assert.Error(t, err, "Keys without a previous value should still fail")
+	assert.Equal(t, uint64(1), mc.Stats().StaleServed)
+
+	mc.SetServeStaleOnError(false)
+	_, err = mc.Get("a")
+	assert.Error(t, err)
+}
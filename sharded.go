@@ -0,0 +1,552 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ShardedMultiCache partitions keys across a fixed number of [MultiCache] shards, each with its own lock.
+// This reduces lock contention for write-heavy workloads on machines with many cores, where a single MultiCache lock can become a bottleneck.
+//
+// Each shard is configured identically, and limits such as SetMaxEntries are divided evenly between shards.
+// Because of this, eviction decisions are made per shard, rather than across the whole ShardedMultiCache.
+type ShardedMultiCache[K comparable, V any] struct {
+	shards []*MultiCache[K, V]
+	seed   maphash.Seed
+}
+
+// NewShardedMulti will create a new ShardedMultiCache with the given number of shards and loader.
+// A good starting point for shards is a small multiple of runtime.GOMAXPROCS.
+//
+// If shards <= 0, or the loader is nil, then this function will panic.
+func NewShardedMulti[K comparable, V any](shards int, loader MultiLoaderFunc[K, V]) *ShardedMultiCache[K, V] {
+	if shards <= 0 {
+		panic("shards <= 0")
+	}
+	if loader == nil {
+		panic("nil loader")
+	}
+	s := &ShardedMultiCache[K, V]{
+		shards: make([]*MultiCache[K, V], shards),
+		seed:   maphash.MakeSeed(),
+	}
+	for i := range s.shards {
+		s.shards[i] = NewMulti[K, V](loader)
+	}
+	return s
+}
+
+// shard returns the MultiCache responsible for key.
+// Keys are normalized first, so keys that normalize to the same key are held by the same shard.
+func (s *ShardedMultiCache[K, V]) shard(key K) *MultiCache[K, V] {
+	if len(s.shards) == 1 {
+		return s.shards[0]
+	}
+	var h maphash.Hash
+	h.SetSeed(s.seed)
+	hashKey(&h, s.shards[0].normalize(key))
+	return s.shards[h.Sum64()%uint64(len(s.shards))]
+}
+
+// hashKey writes a representation of key to h.
+// Keys that are equal with == always write the same representation, so they're routed to the same shard.
+// Common key types are written directly, and any other type is written by walking its value with reflection.
+func hashKey[K comparable](h *maphash.Hash, key K) {
+	var buf [8]byte
+	switch k := any(key).(type) {
+	case string:
+		_, _ = h.WriteString(k)
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case int32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case int16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case int8:
+		_ = h.WriteByte(byte(k))
+	case uint:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], k)
+		_, _ = h.Write(buf[:])
+	case uint32:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case uint16:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case uint8:
+		_ = h.WriteByte(k)
+	case uintptr:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+		_, _ = h.Write(buf[:])
+	case bool:
+		if k {
+			_ = h.WriteByte(1)
+		} else {
+			_ = h.WriteByte(0)
+		}
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(k))
+		_, _ = h.Write(buf[:])
+	case float32:
+		binary.LittleEndian.PutUint64(buf[:], floatBits(float64(k)))
+		_, _ = h.Write(buf[:])
+	default:
+		hashValue(h, reflect.ValueOf(any(key)))
+	}
+}
+
+// floatBits returns the bits of f, with -0 written the same as 0, since they're equal with ==.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// hashValue writes a representation of v to h, following the rules of == for comparable types.
+// Pointers and channels are written by address rather than by what they point to, so a key remains routable after the value it points to changes.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		_, _ = h.Write(buf[:])
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		// A nil interface value.
+		_ = h.WriteByte(0)
+	case reflect.Bool:
+		if v.Bool() {
+			_ = h.WriteByte(1)
+		} else {
+			_ = h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeUint(floatBits(real(c)))
+		writeUint(floatBits(imag(c)))
+	case reflect.String:
+		_, _ = h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Interface:
+		hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).Name == "_" {
+				// Blank fields are ignored by ==.
+				continue
+			}
+			hashValue(h, v.Field(i))
+		}
+	default:
+		// Other kinds aren't comparable, so they can't be part of a key.
+		_, _ = h.WriteString(v.Type().String())
+	}
+}
+
+// Preheat will load the values associated with each key in keys.
+// This will return the first error encountered and stop processing further keys.
+func (s *ShardedMultiCache[K, V]) Preheat(keys []K) error {
+	for _, key := range keys {
+		_, err := s.Get(key)
+		if err != nil {
+			return fmt.Errorf("error preheating cache with key '%v': %w", key, err)
+		}
+	}
+	return nil
+}
+
+// PreheatEach is the same as Preheat, except that it will attempt to load every key, even if some of them fail.
+// See [MultiCache.PreheatEach] for details.
+func (s *ShardedMultiCache[K, V]) PreheatEach(keys []K) error {
+	var errs []error
+	for _, key := range keys {
+		_, err := s.Get(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error preheating cache with key '%v': %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PreheatFrom will cache every value in vals by its key, without calling the loader.
+// See [MultiCache.PreheatFrom] for details.
+func (s *ShardedMultiCache[K, V]) PreheatFrom(vals map[K]V) {
+	for key, val := range vals {
+		s.Set(key, val)
+	}
+}
+
+// Get will return the value associated with key, loading it if needed.
+// See [MultiCache.Get] for details.
+func (s *ShardedMultiCache[K, V]) Get(key K) (V, error) {
+	return s.shard(key).Get(key)
+}
+
+// GetCtx is the same as Get, and is provided for parity with [MultiCache.GetCtx].
+// The loader of a ShardedMultiCache isn't context-aware, so ctx is not used.
+func (s *ShardedMultiCache[K, V]) GetCtx(ctx context.Context, key K) (V, error) {
+	return s.shard(key).GetCtx(ctx, key)
+}
+
+// GetIfPresent will return the value associated with key and true if a valid value is cached, without loading it.
+// See [MultiCache.GetIfPresent] for details.
+func (s *ShardedMultiCache[K, V]) GetIfPresent(key K) (V, bool) {
+	return s.shard(key).GetIfPresent(key)
+}
+
+// MustGet does the same thing as Get, but it will panic if an error occurs.
+func (s *ShardedMultiCache[K, V]) MustGet(key K) V {
+	return s.shard(key).MustGet(key)
+}
+
+// Set will cache val for key without calling the loader, replacing any existing value.
+// See [MultiCache.Set] for details.
+func (s *ShardedMultiCache[K, V]) Set(key K, val V) {
+	s.shard(key).Set(key, val)
+}
+
+// GetOrSet will return the existing value for key and true if a valid value is cached, or cache val and return it with false.
+// See [MultiCache.GetOrSet] for details.
+func (s *ShardedMultiCache[K, V]) GetOrSet(key K, val V) (V, bool) {
+	return s.shard(key).GetOrSet(key, val)
+}
+
+// Invalidate will invalidate the value associated with key, if it exists.
+func (s *ShardedMultiCache[K, V]) Invalidate(key K) {
+	s.shard(key).Invalidate(key)
+}
+
+// Remove will delete the value for key, and return the value that was cached and true if there was one.
+// See [MultiCache.Remove] for details.
+func (s *ShardedMultiCache[K, V]) Remove(key K) (V, bool) {
+	return s.shard(key).Remove(key)
+}
+
+// InvalidateAfter will invalidate the value for key once d has elapsed.
+// See [MultiCache.InvalidateAfter] for details.
+func (s *ShardedMultiCache[K, V]) InvalidateAfter(key K, d time.Duration) {
+	s.shard(key).InvalidateAfter(key, d)
+}
+
+// CancelInvalidateAfter cancels an invalidation for key scheduled with InvalidateAfter, and returns true if one was pending.
+func (s *ShardedMultiCache[K, V]) CancelInvalidateAfter(key K) bool {
+	return s.shard(key).CancelInvalidateAfter(key)
+}
+
+// Clear will invalidate every value in every shard.
+// See [MultiCache.Clear] for details.
+func (s *ShardedMultiCache[K, V]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// InvalidateWhere will invalidate every valid value for which the predicate returns true, and returns the number of values invalidated.
+// See [MultiCache.InvalidateWhere] for details.
+func (s *ShardedMultiCache[K, V]) InvalidateWhere(predicate func(key K, val V) bool) int {
+	var invalidated int
+	for _, shard := range s.shards {
+		invalidated += shard.InvalidateWhere(predicate)
+	}
+	return invalidated
+}
+
+// BumpGeneration logically invalidates every value held in every shard in constant time per shard.
+// See [MultiCache.BumpGeneration] for details.
+func (s *ShardedMultiCache[K, V]) BumpGeneration() {
+	for _, shard := range s.shards {
+		shard.BumpGeneration()
+	}
+}
+
+// OnAnyInvalidate sets a function that will be called with the key of any value that's invalidated in any shard.
+// See [MultiCache.OnAnyInvalidate] for details.
+func (s *ShardedMultiCache[K, V]) OnAnyInvalidate(fn OnInvalidateKeyFunc[K]) {
+	for _, shard := range s.shards {
+		shard.OnAnyInvalidate(fn)
+	}
+}
+
+// OnEvict sets a function that will be called whenever a value is removed or replaced in any shard.
+// See [MultiCache.OnEvict] for details.
+func (s *ShardedMultiCache[K, V]) OnEvict(fn OnEvictFunc[K, V]) {
+	for _, shard := range s.shards {
+		shard.OnEvict(fn)
+	}
+}
+
+// SetTTLPolicy sets the time to live policy for all shards.
+// See [MultiCache.SetTTLPolicy] for details.
+//
+// This method will panic if ttl <= 0.
+func (s *ShardedMultiCache[K, V]) SetTTLPolicy(ttl time.Duration) {
+	for _, shard := range s.shards {
+		shard.SetTTLPolicy(ttl)
+	}
+}
+
+// SetKeyNormalizer sets the key normalizer for all shards.
+// See [MultiCache.SetKeyNormalizer] for details.
+func (s *ShardedMultiCache[K, V]) SetKeyNormalizer(normalizer KeyNormalizer[K]) {
+	for _, shard := range s.shards {
+		shard.SetKeyNormalizer(normalizer)
+	}
+}
+
+// SetLoadTimeout sets the load timeout for all shards.
+// See [MultiCache.SetLoadTimeout] for details.
+//
+// This method will panic if d <= 0.
+func (s *ShardedMultiCache[K, V]) SetLoadTimeout(d time.Duration) {
+	for _, shard := range s.shards {
+		shard.SetLoadTimeout(d)
+	}
+}
+
+// ExpiresAt returns the time that the value for key will expire, and true if a valid value is cached.
+// See [MultiCache.ExpiresAt] for details.
+func (s *ShardedMultiCache[K, V]) ExpiresAt(key K) (time.Time, bool) {
+	return s.shard(key).ExpiresAt(key)
+}
+
+// TTLRemaining returns the time remaining until the value for key expires, and true if a valid value is cached.
+// See [MultiCache.TTLRemaining] for details.
+func (s *ShardedMultiCache[K, V]) TTLRemaining(key K) (time.Duration, bool) {
+	return s.shard(key).TTLRemaining(key)
+}
+
+// SetAdaptiveTTL enables adjusting the time to live of each value in all shards, based on how often it changes.
+// See [MultiCache.SetAdaptiveTTL] for details.
+//
+// This method will panic if minTTL <= 0, or maxTTL < minTTL.
+func (s *ShardedMultiCache[K, V]) SetAdaptiveTTL(minTTL, maxTTL time.Duration, equal func(a, b V) bool) {
+	for _, shard := range s.shards {
+		shard.SetAdaptiveTTL(minTTL, maxTTL, equal)
+	}
+}
+
+// SetMissingKeyFilter enables a filter of keys known not to exist in each shard, where each shard's filter is sized for its share of expectedKeys.
+// See [MultiCache.SetMissingKeyFilter] for details.
+//
+// This method will panic if expectedKeys <= 0, or falsePositiveRate isn't between 0 and 1.
+func (s *ShardedMultiCache[K, V]) SetMissingKeyFilter(expectedKeys int, falsePositiveRate float64) {
+	if expectedKeys <= 0 {
+		panic("expected keys <= 0")
+	}
+	perShard := (expectedKeys + len(s.shards) - 1) / len(s.shards)
+	for _, shard := range s.shards {
+		shard.SetMissingKeyFilter(perShard, falsePositiveRate)
+	}
+}
+
+// SetEarlyRefresh enables probabilistic early refreshing for all shards.
+// See [MultiCache.SetEarlyRefresh] for details.
+//
+// This method will panic if beta <= 0.
+func (s *ShardedMultiCache[K, V]) SetEarlyRefresh(beta float64) {
+	for _, shard := range s.shards {
+		shard.SetEarlyRefresh(beta)
+	}
+}
+
+// SetLoadShedding rejects new loads in each shard while maxInFlight loads are already in progress in that shard.
+// See [MultiCache.SetLoadShedding] for details.
+//
+// This method will panic if maxInFlight <= 0.
+func (s *ShardedMultiCache[K, V]) SetLoadShedding(maxInFlight int) {
+	for _, shard := range s.shards {
+		shard.SetLoadShedding(maxInFlight)
+	}
+}
+
+// Pin exempts the value for key from expiration and eviction until Unpin is called.
+// See [MultiCache.Pin] for details.
+func (s *ShardedMultiCache[K, V]) Pin(key K) {
+	s.shard(key).Pin(key)
+}
+
+// Unpin reverses Pin, so the value for key will expire and may be evicted as normal.
+// See [MultiCache.Unpin] for details.
+func (s *ShardedMultiCache[K, V]) Unpin(key K) {
+	s.shard(key).Unpin(key)
+}
+
+// SetMaxEntries limits the number of values held in the ShardedMultiCache to approximately n.
+// The limit is divided evenly between shards, rounding up, so each shard may hold at least one value.
+// See [MultiCache.SetMaxEntries] for details.
+//
+// This method will panic if n <= 0.
+func (s *ShardedMultiCache[K, V]) SetMaxEntries(n int) {
+	if n <= 0 {
+		panic("max entries <= 0")
+	}
+	perShard := (n + len(s.shards) - 1) / len(s.shards)
+	for _, shard := range s.shards {
+		shard.SetMaxEntries(perShard)
+	}
+}
+
+// SetEvictionPolicy sets the [EvictionPolicy] used by each shard.
+// See [MultiCache.SetEvictionPolicy] for details.
+func (s *ShardedMultiCache[K, V]) SetEvictionPolicy(policy EvictionPolicy) {
+	for _, shard := range s.shards {
+		shard.SetEvictionPolicy(policy)
+	}
+}
+
+// RemoveExpired will remove every expired value from each shard, and returns the number of values removed.
+func (s *ShardedMultiCache[K, V]) RemoveExpired() int {
+	var removed int
+	for _, shard := range s.shards {
+		removed += shard.RemoveExpired()
+	}
+	return removed
+}
+
+// SetEventHandler sets a handler that will receive an [Event] for each hit, miss, load, invalidation, and eviction in any shard.
+// See [MultiCache.SetEventHandler] for details.
+func (s *ShardedMultiCache[K, V]) SetEventHandler(source string, handler EventHandler) {
+	for _, shard := range s.shards {
+		shard.SetEventHandler(source, handler)
+	}
+}
+
+// Stats returns the sum of each shard's [Stats].
+func (s *ShardedMultiCache[K, V]) Stats() Stats {
+	var stats Stats
+	for _, shard := range s.shards {
+		stats = stats.add(shard.Stats())
+	}
+	return stats
+}
+
+// SetSizeEstimator sets the [Weigher] used by EstimatedBytes to estimate the size of each value, in bytes.
+// See [MultiCache.SetSizeEstimator] for details.
+func (s *ShardedMultiCache[K, V]) SetSizeEstimator(estimator Weigher[K, V]) {
+	for _, shard := range s.shards {
+		shard.SetSizeEstimator(estimator)
+	}
+}
+
+// EstimatedBytes returns the sum of each shard's estimated memory usage, in bytes.
+// See [MultiCache.EstimatedBytes] for details.
+func (s *ShardedMultiCache[K, V]) EstimatedBytes() int64 {
+	var total int64
+	for _, shard := range s.shards {
+		total += shard.EstimatedBytes()
+	}
+	return total
+}
+
+// Inspect returns an [EntryInfo] for each valid value currently held in all shards, ordered by the string representation of each key.
+// See [MultiCache.Inspect] for details.
+func (s *ShardedMultiCache[K, V]) Inspect() []EntryInfo {
+	var infos []EntryInfo
+	for _, shard := range s.shards {
+		infos = append(infos, shard.Inspect()...)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return fmt.Sprint(infos[i].Key) < fmt.Sprint(infos[j].Key)
+	})
+	return infos
+}
+
+// Len returns the number of valid values currently held in all shards.
+func (s *ShardedMultiCache[K, V]) Len() int {
+	var n int
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Keys returns the keys of all valid values currently held in all shards, in no particular order.
+func (s *ShardedMultiCache[K, V]) Keys() []K {
+	var keys []K
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// ForEach calls fn with each valid key and value currently held in all shards, in no particular order.
+// Iteration will stop if fn returns false.
+// See [MultiCache.ForEach] for details.
+func (s *ShardedMultiCache[K, V]) ForEach(fn func(key K, val V) bool) {
+	for _, shard := range s.shards {
+		stopped := false
+		shard.ForEach(func(key K, val V) bool {
+			if !fn(key, val) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// Snapshot returns a copy of every valid key and value in all shards.
+// Each shard is copied separately, so the result is consistent within each shard, but not across shards.
+// See [MultiCache.Snapshot] for details.
+func (s *ShardedMultiCache[K, V]) Snapshot() map[K]V {
+	snapshot := map[K]V{}
+	for _, shard := range s.shards {
+		for key, val := range shard.Snapshot() {
+			snapshot[key] = val
+		}
+	}
+	return snapshot
+}
+
+// ForEachByRecency calls fn with each valid key and value in all shards, starting with the least recently used.
+// See [MultiCache.ForEachByRecency] for details.
+func (s *ShardedMultiCache[K, V]) ForEachByRecency(fn func(key K, val V) bool) {
+	visitOrdered(s.snapshotOrdered(), byRecency[K, V], fn)
+}
+
+// ForEachByExpiration calls fn with each valid key and value in all shards, starting with the value that will expire soonest.
+// See [MultiCache.ForEachByExpiration] for details.
+func (s *ShardedMultiCache[K, V]) ForEachByExpiration(fn func(key K, val V) bool) {
+	visitOrdered(s.snapshotOrdered(), byExpiration[K, V], fn)
+}
+
+// snapshotOrdered returns an orderedEntry for each valid value in all shards.
+func (s *ShardedMultiCache[K, V]) snapshotOrdered() []orderedEntry[K, V] {
+	var snapshot []orderedEntry[K, V]
+	for _, shard := range s.shards {
+		snapshot = append(snapshot, shard.snapshotOrdered()...)
+	}
+	return snapshot
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedMultiCache(t *testing.T) {
+	var loads atomic.Int64
+	sc := NewShardedMulti[string, string](8, func(key string) (string, error) {
+		loads.Add(1)
+		return "value-" + key, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d", j)
+				val, err := sc.Get(key)
+				assert.NoError(t, err)
+				assert.Equal(t, "value-"+key, val)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(100), loads.Load())
+	assert.Equal(t, 100, sc.Len())
+
+	// Keys should be spread across shards.
+	var used int
+	for _, shard := range sc.shards {
+		if shard.Len() > 0 {
+			used++
+		}
+	}
+	assert.Greater(t, used, 1)
+
+	sc.Invalidate("1")
+	_, ok := sc.GetIfPresent("1")
+	assert.False(t, ok)
+	sc.Set("1", "set")
+	assert.Equal(t, "set", sc.MustGet("1"))
+
+	keys := sc.Keys()
+	sort.Strings(keys)
+	assert.Len(t, keys, 100)
+	assert.Equal(t, "0", keys[0])
+}
+
+func TestShardedMultiCache_SetMaxEntries(t *testing.T) {
+	sc := NewShardedMulti[int, int](4, func(key int) (int, error) {
+		return key, nil
+	})
+	sc.SetMaxEntries(8)
+	for i := 0; i < 100; i++ {
+		_, _ = sc.Get(i)
+	}
+	assert.LessOrEqual(t, sc.Len(), 8)
+
+	assert.Panics(t, func() {
+		NewShardedMulti[int, int](0, func(key int) (int, error) {
+			return key, nil
+		})
+	})
+}
+
+func TestHashKey_Struct(t *testing.T) {
+	type compound struct {
+		a int
+		b string
+	}
+	sc := NewShardedMulti[compound, string](4, func(key compound) (string, error) {
+		return fmt.Sprintf("%d:%s", key.a, key.b), nil
+	})
+	key := compound{a: 1, b: "x"}
+	assert.Same(t, sc.shard(key), sc.shard(compound{a: 1, b: "x"}))
+	assert.Equal(t, "1:x", sc.MustGet(key))
+}
+
+func TestHashKey_Pointer(t *testing.T) {
+	type node struct {
+		name string
+	}
+	sc := NewShardedMulti[*node, string](16, func(key *node) (string, error) {
+		return key.name, nil
+	})
+	keys := make([]*node, 32)
+	for i := range keys {
+		keys[i] = &node{name: fmt.Sprint(i)}
+		assert.Equal(t, fmt.Sprint(i), sc.MustGet(keys[i]))
+	}
+	for i, key := range keys {
+		shard := sc.shard(key)
+		key.name = "changed"
+		assert.Same(t, shard, sc.shard(key), "A pointer key should be routed by address")
+		val, ok := sc.GetIfPresent(key)
+		assert.True(t, ok, "A pointer key should stay routable after the value it points to changes")
+		assert.Equal(t, fmt.Sprint(i), val)
+	}
+}
+
+func TestHashKey_Kinds(t *testing.T) {
+	type named string
+	type compound struct {
+		a [2]int8
+		f float64
+		b bool
+		n named
+		i any
+	}
+	seed := maphash.MakeSeed()
+	sum := func(key any) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		hashKey(&h, key)
+		return h.Sum64()
+	}
+	for _, key := range []any{true, 1.5, float32(2), int8(-1), int16(3), uint8(4), uint16(5), uintptr(6), named("x"), [3]int{1, 2, 3}, compound{a: [2]int8{1, 2}, f: 1, b: true, n: "y", i: 7}} {
+		assert.Equal(t, sum(key), sum(key), "Hashing %#v should be deterministic", key)
+	}
+	negZero := math.Copysign(0, -1)
+	assert.Equal(t, sum(compound{f: 0}), sum(compound{f: negZero}), "Keys equal with == should hash the same")
+	assert.Equal(t, sum(0.0), sum(negZero))
+	assert.NotEqual(t, sum(compound{i: 1}), sum(compound{i: 2}))
+}
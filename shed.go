@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// loadShedder rejects loads once too many are in flight.
+type loadShedder struct {
+	maxInFlight int64
+	inFlight    atomic.Int64
+}
+
+// tryAcquire returns true if another load may start, in which case release must be called once it's done.
+func (s *loadShedder) tryAcquire() bool {
+	if s.inFlight.Add(1) > s.maxInFlight {
+		s.inFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+func (s *loadShedder) release() {
+	s.inFlight.Add(-1)
+}
+
+// SetLoadShedding rejects new loads with [ErrOverloaded] while maxInFlight loads are already in progress, rather than adding to the load on the underlying data source.
+// This keeps a service responsive during a backend incident, since requests fail fast instead of waiting on a saturated data source.
+// Loads waiting for a slot from SetMaxConcurrentLoads are counted as in progress.
+//
+// Valid cached values are still returned as usual.
+// To return the previous value for a key instead of ErrOverloaded, combine this with SetServeStaleOnError or SetStaleWhileRevalidate.
+// Background refreshes are skipped while the MultiCache is overloaded, and the number of rejected loads is reported by [Stats].
+// This method will panic if maxInFlight <= 0.
+func (m *MultiCache[K, V]) SetLoadShedding(maxInFlight int) {
+	if maxInFlight <= 0 {
+		panic("max in flight <= 0")
+	}
+	m.shedder.Store(&loadShedder{maxInFlight: int64(maxInFlight)})
+}
+
+// shedLoader wraps loader so that it returns [ErrOverloaded] without calling loader if s has too many loads in flight, and sets shed to true.
+func shedLoader[V any](s *loadShedder, loader LoaderFunc[V], shed *bool) LoaderFunc[V] {
+	return func() (V, error) {
+		if !s.tryAcquire() {
+			*shed = true
+			var mt V
+			return mt, ErrOverloaded
+		}
+		defer s.release()
+		return loader()
+	}
+}
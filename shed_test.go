@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetLoadShedding(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+		return key, nil
+	})
+	mc.SetLoadShedding(1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Equal(t, 1, mc.MustGet(1))
+	}()
+	<-started
+
+	_, err := mc.Get(2)
+	assert.ErrorIs(t, err, ErrOverloaded)
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, 2, loadErr.Key)
+	assert.Equal(t, int32(1), calls.Load(), "The loader should not be called while overloaded")
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 2, mc.MustGet(2))
+	stats := mc.Stats()
+	assert.Equal(t, uint64(1), stats.LoadsShed)
+	assert.Equal(t, uint64(2), stats.Loads)
+	assert.Equal(t, uint64(0), stats.LoadFailures)
+
+	assert.Panics(t, func() {
+		mc.SetLoadShedding(0)
+	})
+}
+
+func TestMultiCache_SetLoadShedding_Stale(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	mc := NewMulti[int, int32](func(key int) (int32, error) {
+		n := calls.Add(1)
+		if key == 0 {
+			close(started)
+			<-release
+		}
+		return n, nil
+	})
+	mc.SetTTLPolicy(10 * time.Millisecond)
+	mc.SetServeStaleOnError(true)
+	mc.SetLoadShedding(1)
+
+	assert.Equal(t, int32(1), mc.MustGet(1))
+	time.Sleep(20 * time.Millisecond)
+	go mc.MustGet(0)
+	<-started
+
+	val, err := mc.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), val, "The previous value should be served while overloaded")
+	close(release)
+}
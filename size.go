@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"unsafe"
+)
+
+// SetSizeEstimator sets the [Weigher] used by EstimatedBytes to estimate the size of each value, in bytes.
+// This should be set when values hold significant memory through pointers, slices, or maps, which the default estimate doesn't account for.
+// Passing nil will restore the default estimate.
+func (m *MultiCache[K, V]) SetSizeEstimator(estimator Weigher[K, V]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.sizeEstimator = estimator
+	})
+}
+
+// EstimatedBytes returns an estimate of the memory held by the valid values in the MultiCache, in bytes.
+// This allows operators to monitor the memory footprint of a MultiCache, and alert on it before memory is exhausted.
+//
+// By default, each key and value is estimated by its fixed size, and the contents of strings and byte slices, along with the MultiCache's own bookkeeping for the entry.
+// Memory referenced through pointers, maps, or other slices is only counted if an estimator is set with SetSizeEstimator.
+// Every valid value is inspected, so this should be called periodically, rather than on every request.
+func (m *MultiCache[K, V]) EstimatedBytes() int64 {
+	estimator := m.hooks.Load().sizeEstimator
+	var total int64
+	for _, ke := range m.entries() {
+		val, ok := ke.entry.value.tryCached()
+		if !ok {
+			continue
+		}
+		total += entryOverhead[K, V]()
+		if estimator != nil {
+			total += estimator(ke.key, val)
+			continue
+		}
+		total += shallowSize(ke.key) + shallowSize(val)
+	}
+	return total
+}
+
+// entryOverhead returns the size of the bookkeeping held by a MultiCache for each entry.
+func entryOverhead[K comparable, V any]() int64 {
+	return int64(unsafe.Sizeof(multiEntry[K, V]{}) + unsafe.Sizeof(Value[V]{}))
+}
+
+// shallowSize returns the fixed size of val, along with the contents of strings and byte slices.
+func shallowSize[T any](val T) int64 {
+	size := int64(unsafe.Sizeof(val))
+	switch v := any(val).(type) {
+	case string:
+		size += int64(len(v))
+	case []byte:
+		size += int64(cap(v))
+	}
+	return size
+}
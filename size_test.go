@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_EstimatedBytes(t *testing.T) {
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		return key + key, nil
+	})
+	assert.Equal(t, int64(0), mc.EstimatedBytes())
+
+	mc.MustGet("abc")
+	var (
+		overhead = entryOverhead[string, string]()
+		header   = int64(unsafe.Sizeof(""))
+	)
+	assert.Equal(t, overhead+2*header+3+6, mc.EstimatedBytes())
+
+	mc.SetSizeEstimator(func(key string, val string) int64 {
+		return 100
+	})
+	mc.MustGet("d")
+	assert.Equal(t, 2*(overhead+100), mc.EstimatedBytes())
+
+	mc.Invalidate("abc")
+	assert.Equal(t, overhead+100, mc.EstimatedBytes())
+
+	mc.SetSizeEstimator(nil)
+	assert.Equal(t, overhead+2*header+1+2, mc.EstimatedBytes())
+}
+
+func TestShallowSize(t *testing.T) {
+	assert.Equal(t, int64(8), shallowSize(int64(1)))
+	assert.Equal(t, int64(unsafe.Sizeof([]byte{}))+16, shallowSize(make([]byte, 4, 16)))
+	type pair struct {
+		a, b int32
+	}
+	assert.Equal(t, int64(8), shallowSize(pair{}))
+}
@@ -0,0 +1,28 @@
+package cache
+
+// Snapshot returns a point-in-time copy of every valid key and value in the MultiCache.
+// This is useful for reporting or bulk export, where a consistent view is more important than reading the latest values.
+//
+// The set of keys is consistent, since the MultiCache's read lock is held while values are copied, but no write lock is taken, so reads are never blocked.
+// Values that are being loaded when Snapshot is called don't have a valid value yet, and are not included.
+// If a cloner is set with SetCloner, then each value is cloned.
+func (m *MultiCache[K, V]) Snapshot() map[K]V {
+	m.lock.RLock()
+	snapshot := make(map[K]V, len(m.values))
+	for key, entry := range m.values {
+		if m.isOutdated(entry) || m.isIdle(entry) {
+			continue
+		}
+		// A slow load for one key shouldn't hold the read lock.
+		if val, ok := entry.value.tryCached(); ok {
+			snapshot[key] = val
+		}
+	}
+	m.lock.RUnlock()
+	if m.hooks.Load().cloner != nil {
+		for key, val := range snapshot {
+			snapshot[key] = m.clone(val)
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Snapshot(t *testing.T) {
+	mc := NewMulti[int, []int](func(key int) ([]int, error) {
+		return []int{key}, nil
+	})
+	assert.Empty(t, mc.Snapshot())
+
+	assert.NoError(t, mc.Preheat([]int{1, 2, 3}))
+	mc.Invalidate(2)
+	mc.SetCloner(func(val []int) []int {
+		return append([]int(nil), val...)
+	})
+	snapshot := mc.Snapshot()
+	assert.Equal(t, map[int][]int{1: {1}, 3: {3}}, snapshot)
+
+	snapshot[1][0] = 100
+	assert.Equal(t, []int{1}, mc.MustGet(1), "Snapshot values should be cloned")
+
+	mc.BumpGeneration()
+	assert.Empty(t, mc.Snapshot())
+}
+
+func TestShardedMultiCache_Snapshot(t *testing.T) {
+	s := NewShardedMulti[int, int](4, func(key int) (int, error) {
+		return key * 2, nil
+	})
+	assert.NoError(t, s.Preheat([]int{1, 2, 3, 4, 5}))
+	assert.Equal(t, map[int]int{1: 2, 2: 4, 3: 6, 4: 8, 5: 10}, s.Snapshot())
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// Stats is a point-in-time snapshot of a MultiCache's or Value's aggregate counters.
+// Counters are cumulative from the creation of the cache.
+type Stats struct {
+	// Hits is the number of requests that were served by a valid cached value.
+	Hits uint64
+	// Misses is the number of requests that found no valid cached value.
+	Misses uint64
+	// Loads is the number of values the loader was asked to load, including failed loads.
+	Loads uint64
+	// LoadFailures is the number of loads that returned an error.
+	LoadFailures uint64
+	// Evictions is the number of values removed because they expired or the MultiCache was over capacity.
+	// Values removed by invalidation are not counted.
+	Evictions uint64
+	// StaleServed is the number of times a previous value was returned in place of a load error.
+	// See [MultiCache.SetServeStaleOnError].
+	StaleServed uint64
+	// LoadsShed is the number of loads that were rejected with [ErrOverloaded].
+	// See [MultiCache.SetLoadShedding].
+	LoadsShed uint64
+	// Entries is the number of valid values held in the MultiCache when the snapshot was taken.
+	Entries int
+}
+
+// HitRatio returns the fraction of requests that were hits, or 0 if there have been no requests.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// add returns the sum of s and other.
+func (s Stats) add(other Stats) Stats {
+	return Stats{
+		Hits:         s.Hits + other.Hits,
+		Misses:       s.Misses + other.Misses,
+		Loads:        s.Loads + other.Loads,
+		LoadFailures: s.LoadFailures + other.LoadFailures,
+		Evictions:    s.Evictions + other.Evictions,
+		StaleServed:  s.StaleServed + other.StaleServed,
+		LoadsShed:    s.LoadsShed + other.LoadsShed,
+		Entries:      s.Entries + other.Entries,
+	}
+}
+
+// statsCounter holds the counters used to create Stats.
+// Counters are atomic so they can be updated without holding a lock.
+type statsCounter struct {
+	hits         atomic.Uint64
+	misses       atomic.Uint64
+	loads        atomic.Uint64
+	loadFailures atomic.Uint64
+	evictions    atomic.Uint64
+	staleServed  atomic.Uint64
+	loadsShed    atomic.Uint64
+}
+
+func (c *statsCounter) recordLoads(n int, err error) {
+	c.loads.Add(uint64(n))
+	if err != nil {
+		c.loadFailures.Add(uint64(n))
+	}
+}
+
+func (c *statsCounter) snapshot() Stats {
+	return Stats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Loads:        c.loads.Load(),
+		LoadFailures: c.loadFailures.Load(),
+		Evictions:    c.evictions.Load(),
+		StaleServed:  c.staleServed.Load(),
+		LoadsShed:    c.loadsShed.Load(),
+	}
+}
+
+// Stats returns a snapshot of the Value's counters, which are updated by Get.
+// Entries will be 1 if a valid value is cached, and 0 otherwise.
+// Evictions are not tracked for a Value.
+func (c *Value[T]) Stats() Stats {
+	stats := c.stats.snapshot()
+	if _, ok := c.TryGet(); ok {
+		stats.Entries = 1
+	}
+	return stats
+}
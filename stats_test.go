@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Stats(t *testing.T) {
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		if key < 0 {
+			return 0, errors.New("negative key")
+		}
+		return key, nil
+	})
+	assert.Equal(t, float64(0), mc.Stats().HitRatio())
+
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(1)
+	_, _ = mc.Get(2)
+	_, err := mc.Get(-1)
+	assert.Error(t, err)
+	_, _ = mc.GetIfPresent(3)
+
+	stats := mc.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(4), stats.Misses)
+	assert.Equal(t, uint64(3), stats.Loads)
+	assert.Equal(t, uint64(1), stats.LoadFailures)
+	assert.Equal(t, uint64(0), stats.Evictions)
+	assert.Equal(t, 2, stats.Entries)
+	assert.InDelta(t, 2.0/6.0, stats.HitRatio(), 0.0001)
+
+	mc.SetMaxEntries(1)
+	for _, key := range []int{-1, 1, 2} {
+		mc.Invalidate(key)
+	}
+	stats = mc.Stats()
+	assert.Equal(t, uint64(2), stats.Evictions, "Invalidated values should not be counted as evictions")
+	assert.Equal(t, 0, stats.Entries)
+}
+
+func TestMultiCache_Stats_Batch(t *testing.T) {
+	mc := NewMultiBatch[int, int](func(keys []int) (map[int]int, error) {
+		vals := map[int]int{}
+		for _, key := range keys {
+			if key != 3 {
+				vals[key] = key
+			}
+		}
+		return vals, nil
+	})
+	_, err := mc.GetMulti([]int{1, 2, 3})
+	assert.Error(t, err)
+	stats := mc.Stats()
+	assert.Equal(t, uint64(3), stats.Misses)
+	assert.Equal(t, uint64(3), stats.Loads)
+	assert.Equal(t, uint64(1), stats.LoadFailures)
+	assert.Equal(t, 2, stats.Entries)
+}
@@ -0,0 +1,99 @@
+package cache
+
+// Tagger returns the tags that should be associated with a newly loaded value.
+// Tags group related values, so they may be invalidated together with [MultiCache.InvalidateTag].
+type Tagger[K comparable, V any] func(key K, val V) []string
+
+// SetTagger sets a [Tagger] that will be called with every newly loaded or set value.
+// The returned tags replace any tags previously associated with the key.
+// Values that are already cached won't be tagged until they're loaded again, but tags may be added to them with Tag.
+//
+// The tagger is called while the value's key is locked, so it must not call methods on the same MultiCache.
+func (m *MultiCache[K, V]) SetTagger(tagger Tagger[K, V]) {
+	m.updateHooks(func(hooks *multiHooks[K, V]) {
+		hooks.tagger = tagger
+	})
+}
+
+// Tag associates the given tags with the value for key, in addition to any tags it already has.
+// The returned bool will be false if there is no entry for key.
+func (m *MultiCache[K, V]) Tag(key K, tags ...string) bool {
+	key = m.normalize(key)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.values[key]
+	if !ok {
+		return false
+	}
+	for _, tag := range tags {
+		m.addTag(key, entry, tag)
+	}
+	return true
+}
+
+// Tags returns the tags currently associated with the value for key.
+func (m *MultiCache[K, V]) Tags(key K) []string {
+	key = m.normalize(key)
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	entry, ok := m.values[key]
+	if !ok || len(entry.tags) == 0 {
+		return nil
+	}
+	return append([]string(nil), entry.tags...)
+}
+
+// InvalidateTag will invalidate every value associated with tag, and returns the number of values invalidated.
+// This is useful for flushing everything derived from a shared source, such as every value built from a database table that just changed.
+func (m *MultiCache[K, V]) InvalidateTag(tag string) int {
+	m.lock.Lock()
+	defer m.unlock()
+	var invalidated int
+	for key := range m.tags[tag] {
+		if m.remove(key, ReasonInvalidated) {
+			invalidated++
+		}
+	}
+	return invalidated
+}
+
+// applyTags replaces the tags of the entry for key.
+func (m *MultiCache[K, V]) applyTags(key K, entry *multiEntry[K, V], tags []string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.values[key] != entry {
+		return
+	}
+	m.untag(key, entry)
+	for _, tag := range tags {
+		m.addTag(key, entry, tag)
+	}
+}
+
+// addTag associates tag with the entry for key.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) addTag(key K, entry *multiEntry[K, V], tag string) {
+	keys, ok := m.tags[tag]
+	if !ok {
+		keys = map[K]struct{}{}
+		m.tags[tag] = keys
+	}
+	if _, ok := keys[key]; ok {
+		return
+	}
+	keys[key] = struct{}{}
+	entry.tags = append(entry.tags, tag)
+}
+
+// untag removes every tag from the entry for key.
+// This must be called while holding the write lock.
+func (m *MultiCache[K, V]) untag(key K, entry *multiEntry[K, V]) {
+	for _, tag := range entry.tags {
+		keys := m.tags[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+	entry.tags = nil
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_InvalidateTag(t *testing.T) {
+	mc := NewMulti[string, string](func(key string) (string, error) {
+		return strings.ToUpper(key), nil
+	})
+	mc.SetTagger(func(key string, _ string) []string {
+		table, _, _ := strings.Cut(key, ":")
+		return []string{"table:" + table}
+	})
+	assert.NoError(t, mc.Preheat([]string{"orders:1", "orders:2", "users:1"}))
+	assert.Equal(t, []string{"table:orders"}, mc.Tags("orders:1"))
+
+	var invalidated []string
+	mc.OnAnyInvalidate(func(key string) {
+		invalidated = append(invalidated, key)
+	})
+	assert.Equal(t, 2, mc.InvalidateTag("table:orders"))
+	assert.ElementsMatch(t, []string{"orders:1", "orders:2"}, invalidated)
+	assert.Equal(t, 1, mc.Len())
+	assert.Equal(t, 0, mc.InvalidateTag("table:orders"))
+
+	assert.True(t, mc.Tag("users:1", "report"))
+	assert.False(t, mc.Tag("missing", "report"))
+	assert.ElementsMatch(t, []string{"table:users", "report"}, mc.Tags("users:1"))
+	assert.Equal(t, 1, mc.InvalidateTag("report"))
+	assert.Nil(t, mc.Tags("users:1"))
+}
+
+func TestMultiCache_SetTagger_Reload(t *testing.T) {
+	var version int
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		version++
+		return version, nil
+	})
+	mc.SetTagger(func(_ int, val int) []string {
+		return []string{fmt.Sprintf("v%d", val)}
+	})
+	_, _ = mc.Get(1)
+	assert.Equal(t, []string{"v1"}, mc.Tags(1))
+
+	// Tags are replaced when the value is replaced.
+	mc.Set(1, 5)
+	assert.Equal(t, []string{"v5"}, mc.Tags(1))
+	assert.Equal(t, 0, mc.InvalidateTag("v1"))
+	assert.Equal(t, 1, mc.InvalidateTag("v5"))
+}
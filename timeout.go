@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SetLoadTimeout bounds how long each call to the loader may take.
+// If a load takes longer than d, then callers waiting for it will receive an error wrapping [ErrLoadTimeout], and the next request for the key will load it again.
+// This keeps one stuck key from blocking its callers indefinitely.
+//
+// A loader given to [NewMultiCtx] will receive a context that is cancelled once d has elapsed, and should return promptly when it's done.
+// Other loaders can't be cancelled, so they will continue in the background until they return, and their result will be discarded.
+// This method will panic if d <= 0.
+func (m *MultiCache[K, V]) SetLoadTimeout(d time.Duration) {
+	if d <= 0 {
+		panic("load timeout <= 0")
+	}
+	m.loadTimeout.Store(int64(d))
+}
+
+// timeoutLoader returns a LoaderFunc that calls load with a context derived from ctx, which is cancelled after d.
+func timeoutLoader[V any](ctx context.Context, d time.Duration, load func(ctx context.Context) (V, error)) LoaderFunc[V] {
+	return func() (V, error) {
+		return awaitLoad(ctx, d, load)
+	}
+}
+
+// timeoutTTLLoader is the same as timeoutLoader, but for a LoaderTTLFunc.
+// The time to live is returned along with the value once the load completes, rather than being set by the load itself, so a load that's abandoned after d can't change it.
+func timeoutTTLLoader[V any](ctx context.Context, d time.Duration, load func(ctx context.Context) (V, time.Duration, error)) LoaderTTLFunc[V] {
+	type loaded struct {
+		val V
+		ttl time.Duration
+	}
+	return func() (V, time.Duration, error) {
+		res, err := awaitLoad(ctx, d, func(ctx context.Context) (loaded, error) {
+			val, ttl, err := load(ctx)
+			return loaded{val: val, ttl: ttl}, err
+		})
+		return res.val, res.ttl, err
+	}
+}
+
+// awaitLoad calls load in a new goroutine, and waits until it returns or d has elapsed.
+// If ctx is done first, then the context's error is returned instead of [ErrLoadTimeout].
+func awaitLoad[T any](ctx context.Context, d time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	loadCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, err := load(loadCtx)
+		done <- result{val: val, err: err}
+	}()
+	var res result
+	select {
+	case res = <-done:
+	case <-loadCtx.Done():
+		res.err = loadCtx.Err()
+	}
+	if res.err != nil && errors.Is(res.err, context.DeadlineExceeded) && ctx.Err() == nil {
+		res.err = fmt.Errorf("%w after %v", ErrLoadTimeout, d)
+	}
+	return res.val, res.err
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiCache_SetLoadTimeout(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	mc := NewMulti[int, int](func(key int) (int, error) {
+		if calls.Add(1) == 1 {
+			<-release
+		}
+		return key, nil
+	})
+	defer close(release)
+	mc.SetLoadTimeout(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := mc.Get(1)
+	assert.ErrorIs(t, err, ErrLoadTimeout)
+	var loadErr *LoadError
+	require.True(t, errors.As(err, &loadErr))
+	assert.Equal(t, 1, loadErr.Key)
+	assert.Less(t, time.Since(start), time.Second)
+
+	val, err := mc.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, int32(2), calls.Load())
+
+	assert.Panics(t, func() {
+		mc.SetLoadTimeout(0)
+	})
+}
+
+func TestMultiCache_SetLoadTimeout_Ctx(t *testing.T) {
+	cancelled := make(chan error, 1)
+	mc := NewMultiCtx[int, int](func(ctx context.Context, key int) (int, error) {
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return 0, ctx.Err()
+	})
+	mc.SetLoadTimeout(10 * time.Millisecond)
+
+	_, err := mc.Get(1)
+	assert.ErrorIs(t, err, ErrLoadTimeout)
+	assert.ErrorIs(t, <-cancelled, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = mc.GetCtx(ctx, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrLoadTimeout)
+}
+
+func TestMultiCache_SetLoadTimeout_TTL(t *testing.T) {
+	var (
+		calls     atomic.Int32
+		release   = make(chan struct{})
+		abandoned = make(chan struct{})
+	)
+	mc := NewMultiWithTTL[int, int](func(key int) (int, time.Duration, error) {
+		if calls.Add(1) == 1 {
+			defer close(abandoned)
+			<-release
+			return -1, time.Millisecond, nil
+		}
+		return key, time.Hour, nil
+	})
+	mc.SetLoadTimeout(10 * time.Millisecond)
+
+	_, err := mc.Get(1)
+	assert.ErrorIs(t, err, ErrLoadTimeout)
+	val, err := mc.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	// The abandoned load finishes while the key is read, and must not change the time to live of the newer value.
+	close(release)
+	for i := 0; i < 100; i++ {
+		_, _ = mc.TTLRemaining(1)
+	}
+	<-abandoned
+	remaining, ok := mc.TTLRemaining(1)
+	require.True(t, ok)
+	assert.Greater(t, remaining, time.Minute, "An abandoned load should not set the time to live")
+	assert.Equal(t, 1, mc.MustGet(1))
+}
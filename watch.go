@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// watcher delivers values for a single call to Watch.
+type watcher[V any] struct {
+	mux    sync.Mutex
+	ch     chan V
+	closed bool
+}
+
+// send delivers val without blocking.
+// If the previous value hasn't been received yet, then it's replaced with val, so a slow receiver always sees the latest value.
+func (w *watcher[V]) send(val V) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case <-w.ch:
+	default:
+	}
+	w.ch <- val
+}
+
+func (w *watcher[V]) close() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.closed = true
+	close(w.ch)
+}
+
+// watchers tracks the watchers of each key in a MultiCache.
+type watchers[K comparable, V any] struct {
+	mux  sync.Mutex
+	keys map[K]map[*watcher[V]]struct{}
+}
+
+// Watch returns a channel that will receive each new value loaded or set for key, until ctx is done.
+// This allows components to react to changes, such as to configuration entries or feature flags, without polling.
+// The channel is closed once ctx is done.
+//
+// Values are delivered without blocking the MultiCache, so a receiver that falls behind will only receive the latest value.
+// The current value isn't sent when Watch is called, so Get should be used to retrieve it.
+func (m *MultiCache[K, V]) Watch(ctx context.Context, key K) <-chan V {
+	key = m.normalize(key)
+	w := &watcher[V]{ch: make(chan V, 1)}
+	m.watchers.mux.Lock()
+	if m.watchers.keys == nil {
+		m.watchers.keys = map[K]map[*watcher[V]]struct{}{}
+	}
+	keyWatchers, ok := m.watchers.keys[key]
+	if !ok {
+		keyWatchers = map[*watcher[V]]struct{}{}
+		m.watchers.keys[key] = keyWatchers
+	}
+	keyWatchers[w] = struct{}{}
+	m.watchers.mux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.watchers.mux.Lock()
+		delete(keyWatchers, w)
+		if len(keyWatchers) == 0 {
+			delete(m.watchers.keys, key)
+		}
+		m.watchers.mux.Unlock()
+		w.close()
+	}()
+	return w.ch
+}
+
+// notifyWatchers sends val to every watcher of key.
+// This doesn't block, so it's safe to call while holding the Value's lock.
+func (m *MultiCache[K, V]) notifyWatchers(key K, val V) {
+	m.watchers.mux.Lock()
+	defer m.watchers.mux.Unlock()
+	for w := range m.watchers.keys[key] {
+		w.send(m.clone(val))
+	}
+}
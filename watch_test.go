@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiCache_Watch(t *testing.T) {
+	var version int
+	mc := NewMulti[string, int](func(key string) (int, error) {
+		version++
+		return version, nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := mc.Watch(ctx, "flag")
+	other := mc.Watch(ctx, "other")
+
+	receive := func(ch <-chan int) (int, bool) {
+		select {
+		case val, ok := <-ch:
+			return val, ok
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for a value")
+			return 0, false
+		}
+	}
+
+	assert.Equal(t, 1, mc.MustGet("flag"))
+	val, _ := receive(updates)
+	assert.Equal(t, 1, val)
+
+	mc.Set("flag", 10)
+	val, _ = receive(updates)
+	assert.Equal(t, 10, val)
+
+	// A slow receiver only sees the latest value.
+	mc.Set("flag", 11)
+	mc.Set("flag", 12)
+	val, _ = receive(updates)
+	assert.Equal(t, 12, val)
+	select {
+	case <-other:
+		t.Fatal("Watchers should only receive values for their key")
+	default:
+	}
+
+	cancel()
+	_, ok := receive(updates)
+	assert.False(t, ok, "The channel should be closed when the context is done")
+	assert.Eventually(t, func() bool {
+		mc.watchers.mux.Lock()
+		defer mc.watchers.mux.Unlock()
+		return len(mc.watchers.keys) == 0
+	}, time.Second, 5*time.Millisecond)
+}